@@ -0,0 +1,41 @@
+package browser
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// InTerminal reports whether Command runs $BROWSER, which may be a terminal
+// browser such as w3m that needs the terminal to itself
+func InTerminal() bool {
+	return os.Getenv("BROWSER") != ""
+}
+
+// Command returns the command opening url with $BROWSER when it is set,
+// otherwise with the platform's default handler
+func Command(url string) *exec.Cmd {
+	if browser := os.Getenv("BROWSER"); browser != "" {
+		// $BROWSER may be given with arguments, such as "firefox --new-tab"
+		return exec.Command("sh", "-c", browser+` "$1"`, "sh", url)
+	}
+
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("open", url)
+	case "windows":
+		return exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	}
+
+	return exec.Command("xdg-open", url)
+}
+
+// Open opens url, connecting the browser to the terminal
+func Open(url string) error {
+	cmd := Command(url)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	return cmd.Run()
+}
@@ -2,11 +2,9 @@ package circleci
 
 import (
 	"encoding/json"
-	"fmt"
 	"io"
 	"net/http"
-
-	"github.com/jedrw/circlog/config"
+	"net/url"
 )
 
 const (
@@ -20,16 +18,45 @@ const (
 	NOT_RUN      = "not_run"
 	FAILED       = "failed"
 	ERROR        = "error"
+	ERRORED      = "errored"
+	CREATED      = "created"
 	FAILING      = "failing"
 	ONHOLD       = "on_hold"
 	CANCELED     = "canceled"
 	UNAUTHORIZED = "unauthorized"
+
+	// Upper bound on the number of pages scanned while looking for filtered
+	// items, unless every page was requested
+	maxFilteredPages = 50
 )
 
 type ResponseType interface {
 	Pipeline | Workflow | Job | JobDetails
 }
 
+// IsTerminalStatus reports whether a workflow or job with status has finished
+func IsTerminalStatus(status string) bool {
+	switch status {
+	case SUCCESS, FAILED, ERROR, CANCELED, NOT_RUN, UNAUTHORIZED:
+		return true
+	}
+
+	return false
+}
+
+type ApiError struct {
+	StatusCode int
+	Message    string `json:"message"`
+}
+
+func (err *ApiError) Error() string {
+	if err.Message == "" {
+		return http.StatusText(err.StatusCode)
+	}
+
+	return err.Message
+}
+
 type ApiResponse[T ResponseType] struct {
 	NextPageToken string `json:"next_page_token"`
 	Items         []T    `json:"items"`
@@ -42,54 +69,47 @@ func parseResponseBody[T ResponseType](responseBody []byte) (*ApiResponse[T], er
 	return parsedApiResponse, err
 }
 
-func MakeRequest[T ResponseType](url string, config config.CirclogConfig, numPages int, nextPageToken string) ([]T, string, error) {
-	items := []T{}
-	var branch string
-	newItems := true
-	page := 0
-
-	for newItems && page != numPages {
-		if nextPageToken != "" {
-			nextPageToken = fmt.Sprintf("?page-token=%s", nextPageToken)
-		}
-
-		if nextPageToken != "" && config.Branch != "" {
-			branch = fmt.Sprintf("&branch=%s", config.Branch)
-		} else if config.Branch != "" {
-			branch = fmt.Sprintf("?branch=%s", config.Branch)
-		}
+func newRequest(endpoint string, token string, params url.Values) (*http.Request, error) {
+	requestUrl, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
 
-		endpoint := fmt.Sprintf("%s%s%s", url, nextPageToken, branch)
+	requestUrl.RawQuery = params.Encode()
 
-		req, err := http.NewRequest("GET", endpoint, nil)
-		if err != nil {
-			return items, "", err
-		}
+	req, err := http.NewRequest("GET", requestUrl.String(), nil)
+	if err != nil {
+		return nil, err
+	}
 
-		req.Header.Add("Circle-Token", config.Token)
+	req.Header.Add("Circle-Token", token)
 
-		res, err := http.DefaultClient.Do(req)
-		if err != nil {
-			return items, "", err
-		}
+	return req, nil
+}
 
-		defer res.Body.Close()
-		body, _ := io.ReadAll(res.Body)
+func getRequest(endpoint string, token string, params url.Values) ([]byte, error) {
+	req, err := newRequest(endpoint, token, params)
+	if err != nil {
+		return nil, err
+	}
 
-		parsedResponse, err := parseResponseBody[T](body)
-		if err != nil {
-			return items, "", err
-		}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
 
-		items = append(items, parsedResponse.Items...)
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
 
-		nextPageToken = parsedResponse.NextPageToken
-		if nextPageToken == "" {
-			newItems = false
-		}
+	if res.StatusCode >= http.StatusBadRequest {
+		apiError := &ApiError{StatusCode: res.StatusCode}
+		json.Unmarshal(body, apiError)
 
-		page++
+		return nil, apiError
 	}
 
-	return items, nextPageToken, nil
+	return body, nil
 }
@@ -0,0 +1,445 @@
+package circleci
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/jedrw/circlog/config"
+)
+
+type testPage struct {
+	items         []Workflow
+	nextPageToken string
+}
+
+var testPages = map[string]testPage{
+	"": {
+		items:         []Workflow{{Name: "build", Status: SUCCESS}, {Name: "test", Status: FAILED}},
+		nextPageToken: "page/2+=",
+	},
+	"page/2+=": {
+		items:         []Workflow{{Name: "deploy", Status: FAILED}, {Name: "lint", Status: SUCCESS}},
+		nextPageToken: "page&3",
+	},
+	"page&3": {
+		items:         []Workflow{{Name: "release", Status: SUCCESS}},
+		nextPageToken: "",
+	},
+}
+
+// newTestServer serves pages keyed by the page-token query parameter and
+// records the query string of every request it receives
+func newTestServer(t *testing.T, pages map[string]testPage, queries *[]url.Values) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Circle-Token") != "token" {
+			t.Errorf("expected Circle-Token header %q, got %q", "token", r.Header.Get("Circle-Token"))
+		}
+
+		*queries = append(*queries, r.URL.Query())
+
+		page, ok := pages[r.URL.Query().Get("page-token")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		json.NewEncoder(w).Encode(ApiResponse[Workflow]{
+			NextPageToken: page.nextPageToken,
+			Items:         page.items,
+		})
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestNewRequest(t *testing.T) {
+	tests := []struct {
+		name      string
+		endpoint  string
+		params    url.Values
+		wantQuery string
+	}{
+		{
+			name:      "no params",
+			endpoint:  "https://circleci.com/api/v2/pipeline/abc/workflow",
+			params:    nil,
+			wantQuery: "",
+		},
+		{
+			name:      "branch with slash",
+			endpoint:  "https://circleci.com/api/v2/project/gh/org/repo/pipeline",
+			params:    url.Values{"branch": {"feature/foo"}},
+			wantQuery: "branch=feature%2Ffoo",
+		},
+		{
+			name:      "branch with hash and ampersand",
+			endpoint:  "https://circleci.com/api/v2/project/gh/org/repo/pipeline",
+			params:    url.Values{"branch": {"fix#1&2"}},
+			wantQuery: "branch=fix%231%262",
+		},
+		{
+			name:      "multiple params are sorted",
+			endpoint:  "https://circleci.com/api/v1.1/project/github/org/repo/1/output/2/0",
+			params:    url.Values{"file": {"true"}, "allocation-id": {"a-b/c"}},
+			wantQuery: "allocation-id=a-b%2Fc&file=true",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, err := newRequest(test.endpoint, "token", test.params)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if req.URL.RawQuery != test.wantQuery {
+				t.Errorf("expected query %q, got %q", test.wantQuery, req.URL.RawQuery)
+			}
+
+			if req.Header.Get("Circle-Token") != "token" {
+				t.Errorf("expected Circle-Token header %q, got %q", "token", req.Header.Get("Circle-Token"))
+			}
+		})
+	}
+}
+
+func TestPagerPages(t *testing.T) {
+	tests := []struct {
+		name              string
+		params            url.Values
+		numPages          int
+		nextPageToken     string
+		filter            func(Workflow) bool
+		wantNames         []string
+		wantNextPageToken string
+		wantQueries       []url.Values
+	}{
+		{
+			name:              "single page",
+			numPages:          1,
+			wantNames:         []string{"build", "test"},
+			wantNextPageToken: "page/2+=",
+			wantQueries:       []url.Values{{}},
+		},
+		{
+			name:              "page tokens are not accumulated",
+			numPages:          -1,
+			wantNames:         []string{"build", "test", "deploy", "lint", "release"},
+			wantNextPageToken: "",
+			wantQueries: []url.Values{
+				{},
+				{"page-token": {"page/2+="}},
+				{"page-token": {"page&3"}},
+			},
+		},
+		{
+			name:              "resume from page token",
+			numPages:          1,
+			nextPageToken:     "page/2+=",
+			wantNames:         []string{"deploy", "lint"},
+			wantNextPageToken: "page&3",
+			wantQueries:       []url.Values{{"page-token": {"page/2+="}}},
+		},
+		{
+			name:              "params are sent on every page",
+			params:            url.Values{"branch": {"feature/a#b"}},
+			numPages:          2,
+			wantNames:         []string{"build", "test", "deploy", "lint"},
+			wantNextPageToken: "page&3",
+			wantQueries: []url.Values{
+				{"branch": {"feature/a#b"}},
+				{"branch": {"feature/a#b"}, "page-token": {"page/2+="}},
+			},
+		},
+		{
+			name:     "filter pages until enough matches",
+			numPages: 1,
+			filter: func(workflow Workflow) bool {
+				return workflow.Status == SUCCESS
+			},
+			wantNames:         []string{"build", "lint"},
+			wantNextPageToken: "page&3",
+			wantQueries: []url.Values{
+				{},
+				{"page-token": {"page/2+="}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var queries []url.Values
+			server := newTestServer(t, testPages, &queries)
+
+			pager := NewPager(
+				server.URL,
+				config.CirclogConfig{Token: "token"},
+				test.params,
+				test.nextPageToken,
+				test.filter,
+			)
+
+			workflows, err := pager.Pages(test.numPages)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var names []string
+			for _, workflow := range workflows {
+				names = append(names, workflow.Name)
+			}
+
+			if !reflect.DeepEqual(names, test.wantNames) {
+				t.Errorf("expected items %v, got %v", test.wantNames, names)
+			}
+
+			if pager.NextPageToken() != test.wantNextPageToken {
+				t.Errorf("expected next page token %q, got %q", test.wantNextPageToken, pager.NextPageToken())
+			}
+
+			if !reflect.DeepEqual(queries, test.wantQueries) {
+				t.Errorf("expected queries %v, got %v", test.wantQueries, queries)
+			}
+		})
+	}
+}
+
+func TestPagerItems(t *testing.T) {
+	tests := []struct {
+		name        string
+		take        int
+		filter      func(Workflow) bool
+		wantNames   []string
+		wantQueries int
+	}{
+		{
+			name:        "stops fetching once enough items are taken",
+			take:        2,
+			wantNames:   []string{"build", "test"},
+			wantQueries: 1,
+		},
+		{
+			name:        "fetches the next page on demand",
+			take:        3,
+			wantNames:   []string{"build", "test", "deploy"},
+			wantQueries: 2,
+		},
+		{
+			name:        "iterates every page",
+			take:        -1,
+			wantNames:   []string{"build", "test", "deploy", "lint", "release"},
+			wantQueries: 3,
+		},
+		{
+			name: "filters items",
+			take: 2,
+			filter: func(workflow Workflow) bool {
+				return workflow.Status == FAILED
+			},
+			wantNames:   []string{"test", "deploy"},
+			wantQueries: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var queries []url.Values
+			server := newTestServer(t, testPages, &queries)
+			pager := NewPager(server.URL, config.CirclogConfig{Token: "token"}, nil, "", test.filter)
+
+			var names []string
+			for workflow, err := range pager.Items() {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				names = append(names, workflow.Name)
+				if len(names) == test.take {
+					break
+				}
+			}
+
+			if !reflect.DeepEqual(names, test.wantNames) {
+				t.Errorf("expected items %v, got %v", test.wantNames, names)
+			}
+
+			if len(queries) != test.wantQueries {
+				t.Errorf("expected %d requests, got %d", test.wantQueries, len(queries))
+			}
+		})
+	}
+}
+
+func TestPagerTake(t *testing.T) {
+	tests := []struct {
+		name       string
+		cursor     string
+		limit      int
+		filter     func(Workflow) bool
+		wantNames  []string
+		wantCursor string
+	}{
+		{
+			name:       "limit ends on a page boundary",
+			limit:      2,
+			wantNames:  []string{"build", "test"},
+			wantCursor: "page/2+=",
+		},
+		{
+			name:       "limit ends within a page",
+			limit:      3,
+			wantNames:  []string{"build", "test", "deploy"},
+			wantCursor: "1:page/2+=",
+		},
+		{
+			name:       "resume from within a page",
+			cursor:     "1:page/2+=",
+			limit:      5,
+			wantNames:  []string{"lint", "release"},
+			wantCursor: "",
+		},
+		{
+			name:   "filtered cursor counts skipped items",
+			limit:  1,
+			cursor: "page/2+=",
+			filter: func(workflow Workflow) bool {
+				return workflow.Status == SUCCESS
+			},
+			wantNames:  []string{"lint"},
+			wantCursor: "page&3",
+		},
+		{
+			name:       "zero limit returns nothing",
+			cursor:     "1:page/2+=",
+			limit:      0,
+			wantNames:  nil,
+			wantCursor: "1:page/2+=",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var queries []url.Values
+			server := newTestServer(t, testPages, &queries)
+			pager := NewPager(server.URL, config.CirclogConfig{Token: "token"}, nil, test.cursor, test.filter)
+
+			workflows, err := pager.Take(test.limit)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var names []string
+			for _, workflow := range workflows {
+				names = append(names, workflow.Name)
+			}
+
+			if !reflect.DeepEqual(names, test.wantNames) {
+				t.Errorf("expected items %v, got %v", test.wantNames, names)
+			}
+
+			if pager.Cursor() != test.wantCursor {
+				t.Errorf("expected cursor %q, got %q", test.wantCursor, pager.Cursor())
+			}
+		})
+	}
+}
+
+func TestPagerStop(t *testing.T) {
+	tests := []struct {
+		name        string
+		numPages    int
+		stopAt      string
+		wantNames   []string
+		wantQueries int
+	}{
+		{
+			name:        "stops within the first page",
+			numPages:    -1,
+			stopAt:      "test",
+			wantNames:   []string{"build"},
+			wantQueries: 1,
+		},
+		{
+			name:        "stops at the start of a later page",
+			numPages:    -1,
+			stopAt:      "deploy",
+			wantNames:   []string{"build", "test"},
+			wantQueries: 2,
+		},
+		{
+			name:        "never stopping fetches every page",
+			numPages:    -1,
+			stopAt:      "missing",
+			wantNames:   []string{"build", "test", "deploy", "lint", "release"},
+			wantQueries: 3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var queries []url.Values
+			server := newTestServer(t, testPages, &queries)
+			pager := NewPager(server.URL, config.CirclogConfig{Token: "token"}, nil, "", func(Workflow) bool { return true })
+			pager.stop = func(workflow Workflow) bool {
+				return workflow.Name == test.stopAt
+			}
+
+			workflows, err := pager.Pages(test.numPages)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var names []string
+			for _, workflow := range workflows {
+				names = append(names, workflow.Name)
+			}
+
+			if !reflect.DeepEqual(names, test.wantNames) {
+				t.Errorf("expected items %v, got %v", test.wantNames, names)
+			}
+
+			if len(queries) != test.wantQueries {
+				t.Errorf("expected %d requests, got %d", test.wantQueries, len(queries))
+			}
+
+			if !pager.Done() {
+				t.Errorf("expected pager to be done")
+			}
+		})
+	}
+}
+
+func TestParseCursor(t *testing.T) {
+	tests := []struct {
+		name          string
+		cursor        string
+		wantPageToken string
+		wantSkip      int
+	}{
+		{name: "empty", cursor: "", wantPageToken: "", wantSkip: 0},
+		{name: "plain page token", cursor: "page/2+=", wantPageToken: "page/2+=", wantSkip: 0},
+		{name: "offset into a page", cursor: "3:page/2+=", wantPageToken: "page/2+=", wantSkip: 3},
+		{name: "offset into the first page", cursor: "1:", wantPageToken: "", wantSkip: 1},
+		{name: "page token containing a colon", cursor: "2:a:b", wantPageToken: "a:b", wantSkip: 2},
+		{name: "non-numeric offset", cursor: "abc:def", wantPageToken: "abc:def", wantSkip: 0},
+		{name: "negative offset", cursor: "-1:page", wantPageToken: "-1:page", wantSkip: 0},
+		{name: "missing offset", cursor: ":page", wantPageToken: ":page", wantSkip: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pageToken, skip := parseCursor(test.cursor)
+			if pageToken != test.wantPageToken || skip != test.wantSkip {
+				t.Errorf("expected (%q, %d), got (%q, %d)", test.wantPageToken, test.wantSkip, pageToken, skip)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package circleci
+
+import (
+	"path"
+	"strings"
+	"time"
+)
+
+type PipelineFilter struct {
+	Status      string
+	Since       time.Time
+	Until       time.Time
+	TriggerType string
+	Actor       string
+	Revision    string
+	Mine        bool
+}
+
+type WorkflowFilter struct {
+	Name   string
+	Status string
+}
+
+type JobFilter struct {
+	Name   string
+	Status string
+}
+
+func (filter PipelineFilter) clientSide() bool {
+	return filter.Status != "" ||
+		!filter.Since.IsZero() ||
+		!filter.Until.IsZero() ||
+		filter.TriggerType != "" ||
+		filter.Actor != "" ||
+		filter.Revision != ""
+}
+
+func (filter PipelineFilter) Match(pipeline Pipeline) bool {
+	if filter.Status != "" && pipeline.State != filter.Status {
+		return false
+	}
+
+	if !filter.Since.IsZero() && pipeline.CreatedAt.Before(filter.Since) {
+		return false
+	}
+
+	if !filter.Until.IsZero() && pipeline.CreatedAt.After(filter.Until) {
+		return false
+	}
+
+	if filter.TriggerType != "" && pipeline.Trigger.Type != filter.TriggerType {
+		return false
+	}
+
+	if filter.Actor != "" && pipeline.Trigger.Actor.Login != filter.Actor {
+		return false
+	}
+
+	// Allow abbreviated commit SHAs
+	if filter.Revision != "" && !strings.HasPrefix(pipeline.Vcs.Revision, filter.Revision) {
+		return false
+	}
+
+	return true
+}
+
+func (filter WorkflowFilter) clientSide() bool {
+	return filter.Name != "" || filter.Status != ""
+}
+
+func (filter WorkflowFilter) Match(workflow Workflow) bool {
+	return matchNameAndStatus(filter.Name, filter.Status, workflow.Name, workflow.Status)
+}
+
+func (filter JobFilter) clientSide() bool {
+	return filter.Name != "" || filter.Status != ""
+}
+
+func (filter JobFilter) Match(job Job) bool {
+	return matchNameAndStatus(filter.Name, filter.Status, job.Name, job.Status)
+}
+
+// ValidateNamePattern reports whether pattern is a valid glob for the Name
+// field of WorkflowFilter and JobFilter.
+func ValidateNamePattern(pattern string) error {
+	_, err := path.Match(pattern, "")
+
+	return err
+}
+
+func matchNameAndStatus(namePattern string, status string, name string, itemStatus string) bool {
+	if status != "" && itemStatus != status {
+		return false
+	}
+
+	if namePattern != "" {
+		matched, _ := path.Match(namePattern, name)
+		return matched
+	}
+
+	return true
+}
@@ -1,6 +1,7 @@
 package circleci
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -46,13 +47,103 @@ type Organisation struct {
 	Name string `json:"name"`
 }
 
+type JobProject struct {
+	Id          string `json:"id"`
+	Slug        string `json:"slug"`
+	Name        string `json:"name"`
+	ExternalUrl string `json:"external_url"`
+}
+
+type JobLatestWorkflow struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type JobPipeline struct {
+	Id string `json:"id"`
+}
+
+// JobInfo is everything the v2 API knows about a job, as opposed to the
+// steps the v1 API returns
+type JobInfo struct {
+	WebUrl         string            `json:"web_url"`
+	Project        JobProject        `json:"project"`
+	ParallelRuns   []ParallelRun     `json:"parallel_runs"`
+	StartedAt      time.Time         `json:"started_at"`
+	LatestWorkflow JobLatestWorkflow `json:"latest_workflow"`
+	Name           string            `json:"name"`
+	Executor       Executor          `json:"executor"`
+	Parallelism    int64             `json:"parallelism"`
+	Status         string            `json:"status"`
+	Number         int64             `json:"number"`
+	Pipeline       JobPipeline       `json:"pipeline"`
+	Duration       int64             `json:"duration"`
+	CreatedAt      time.Time         `json:"created_at"`
+	Messages       []Message         `json:"messages"`
+	Contexts       []Context         `json:"contexts"`
+	Organisation   Organisation      `json:"organization"`
+	QueuedAt       time.Time         `json:"queued_at"`
+	StoppedAt      time.Time         `json:"stopped_at"`
+}
+
+func (job Job) Duration() time.Duration {
+	if job.Status == RUNNING {
+		return time.Since(job.StartedAt).Round(time.Millisecond)
+	}
+
+	return job.StoppedAt.Sub(job.StartedAt).Round(time.Millisecond)
+}
+
+// NamedJobDependencies resolves the ids of the jobs job depends on to their
+// names
+func NamedJobDependencies(job Job, jobs []Job) []string {
+	var namedDependencies []string
+	for _, dependsOnJobId := range job.Dependencies {
+		for _, requiredJob := range jobs {
+			if requiredJob.Id == dependsOnJobId {
+				namedDependencies = append(namedDependencies, requiredJob.Name)
+			}
+		}
+	}
+
+	return namedDependencies
+}
+
+func GetJobInfo(config config.CirclogConfig, jobNumber int64) (JobInfo, error) {
+	endpoint := fmt.Sprintf("%s/project/%s/job/%d", CIRCLECI_ENDPOINT_V2, config.ProjectSlugV2(), jobNumber)
+
+	body, err := getRequest(endpoint, config.Token, nil)
+	if err != nil {
+		return JobInfo{}, err
+	}
+
+	var jobInfo JobInfo
+	err = json.Unmarshal(body, &jobInfo)
+	if err != nil {
+		return JobInfo{}, err
+	}
+
+	return jobInfo, err
+}
+
 func GetWorkflowJobs(config config.CirclogConfig, workflowId string, numPages int, nextPageToken string) ([]Job, string, error) {
-	url := fmt.Sprintf("%s/workflow/%s/job", CIRCLECI_ENDPOINT_V2, workflowId)
+	pager := WorkflowJobsPager(config, workflowId, JobFilter{}, nextPageToken)
 
-	jobs, nextPageToken, err := MakeRequest[Job](url, config, numPages, nextPageToken)
+	jobs, err := pager.Pages(numPages)
 	if err != nil {
-		return []Job{}, nextPageToken, err
+		return []Job{}, pager.NextPageToken(), err
+	}
+
+	return jobs, pager.NextPageToken(), err
+}
+
+func WorkflowJobsPager(config config.CirclogConfig, workflowId string, filter JobFilter, nextPageToken string) *Pager[Job] {
+	endpoint := fmt.Sprintf("%s/workflow/%s/job", CIRCLECI_ENDPOINT_V2, workflowId)
+
+	var match func(Job) bool
+	if filter.clientSide() {
+		match = filter.Match
 	}
 
-	return jobs, nextPageToken, err
+	return NewPager(endpoint, config, nil, nextPageToken, match)
 }
@@ -0,0 +1,276 @@
+package circleci
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jedrw/circlog/config"
+)
+
+// A Pager fetches the pages of a list endpoint on demand
+type Pager[T ResponseType] struct {
+	endpoint      string
+	config        config.CirclogConfig
+	params        url.Values
+	filter        func(T) bool
+	pageToken     string
+	nextPageToken string
+	skip          int
+	lastPageSize  int
+	fetchedPages  int
+	maxPages      int
+	scanLimit     int
+	started       bool
+
+	// Paging ends at the first item stop matches, which is not returned
+	stop func(T) bool
+
+	// The page being iterated by Items and how many of its items have been
+	// consumed
+	page   []T
+	offset int
+}
+
+// NewPager returns a Pager starting from cursor, which is either a page token
+// or a value previously returned by Cursor
+func NewPager[T ResponseType](endpoint string, config config.CirclogConfig, params url.Values, cursor string, filter func(T) bool) *Pager[T] {
+	nextPageToken, skip := parseCursor(cursor)
+
+	return &Pager[T]{
+		endpoint:      endpoint,
+		config:        config,
+		params:        params,
+		filter:        filter,
+		nextPageToken: nextPageToken,
+		skip:          skip,
+	}
+}
+
+func parseCursor(cursor string) (string, int) {
+	offset, pageToken, found := strings.Cut(cursor, ":")
+	if !found {
+		return cursor, 0
+	}
+
+	skip, err := strconv.Atoi(offset)
+	if err != nil || skip < 0 {
+		return cursor, 0
+	}
+
+	return pageToken, skip
+}
+
+// Done reports whether every page has been fetched
+func (pager *Pager[T]) Done() bool {
+	return pager.started && pager.nextPageToken == ""
+}
+
+// NextPageToken is the token of the page the next call to NextPage fetches
+func (pager *Pager[T]) NextPageToken() string {
+	return pager.nextPageToken
+}
+
+// Cursor identifies the first item that has not yet been returned, so that a
+// new Pager can resume from it. It is empty once every item has been returned.
+func (pager *Pager[T]) Cursor() string {
+	if pager.offset > 0 && pager.offset < len(pager.page) {
+		return fmt.Sprintf("%d:%s", pager.offset, pager.pageToken)
+	}
+
+	if !pager.started && pager.skip > 0 {
+		return fmt.Sprintf("%d:%s", pager.skip, pager.nextPageToken)
+	}
+
+	return pager.nextPageToken
+}
+
+func (pager *Pager[T]) fetch() ([]T, error) {
+	params := url.Values{}
+	for key, values := range pager.params {
+		params[key] = values
+	}
+
+	if pager.nextPageToken != "" {
+		params.Set("page-token", pager.nextPageToken)
+	}
+
+	body, err := getRequest(pager.endpoint, pager.config.Token, params)
+	if err != nil {
+		return []T{}, err
+	}
+
+	parsedResponse, err := parseResponseBody[T](body)
+	if err != nil {
+		return []T{}, err
+	}
+
+	pager.started = true
+	pager.pageToken = pager.nextPageToken
+	pager.nextPageToken = parsedResponse.NextPageToken
+	pager.lastPageSize = len(parsedResponse.Items)
+	pager.fetchedPages++
+
+	items := parsedResponse.Items
+	if pager.stop != nil {
+		if i := slices.IndexFunc(items, pager.stop); i >= 0 {
+			items = items[:i]
+			pager.nextPageToken = ""
+		}
+	}
+
+	return items, nil
+}
+
+func (pager *Pager[T]) match(items []T) []T {
+	if pager.filter == nil {
+		return items
+	}
+
+	matches := []T{}
+	for _, item := range items {
+		if pager.filter(item) {
+			matches = append(matches, item)
+		}
+	}
+
+	return matches
+}
+
+// NextPage fetches the next page and returns the items on it that match the
+// filter
+func (pager *Pager[T]) NextPage() ([]T, error) {
+	if pager.Done() {
+		return []T{}, nil
+	}
+
+	items, err := pager.fetch()
+	if err != nil {
+		return []T{}, err
+	}
+
+	items = items[min(pager.skip, len(items)):]
+	pager.skip = 0
+	pager.page, pager.offset = nil, 0
+
+	return pager.match(items), nil
+}
+
+// Pages fetches numPages pages, or every remaining page if numPages is
+// negative. When filtering, a page is only counted once a full page worth of
+// matching items has been collected.
+func (pager *Pager[T]) Pages(numPages int) ([]T, error) {
+	items := []T{}
+	page := 0
+	pageSize := 0
+	scannedPages := 0
+
+	for !pager.Done() && (numPages < 0 || page < numPages) {
+		newItems, err := pager.NextPage()
+		items = append(items, newItems...)
+		if err != nil {
+			return items, err
+		}
+
+		if pager.filter == nil {
+			page++
+			continue
+		}
+
+		if pageSize == 0 {
+			pageSize = pager.lastPageSize
+		}
+
+		if pageSize > 0 {
+			page = len(items) / pageSize
+		}
+
+		scannedPages++
+		if numPages >= 0 && scannedPages == maxFilteredPages {
+			break
+		}
+	}
+
+	return items, nil
+}
+
+// NextMatchingPage fetches pages until one contains items matching the
+// filter and returns them. At most maxFilteredPages pages are scanned.
+func (pager *Pager[T]) NextMatchingPage() ([]T, error) {
+	for scannedPages := 0; !pager.Done() && scannedPages < maxFilteredPages; scannedPages++ {
+		items, err := pager.NextPage()
+		if err != nil || len(items) != 0 {
+			return items, err
+		}
+	}
+
+	return []T{}, nil
+}
+
+// Items lazily iterates over every remaining item, fetching pages as they are
+// needed
+func (pager *Pager[T]) Items() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for {
+			for pager.offset < len(pager.page) {
+				item := pager.page[pager.offset]
+				pager.offset++
+				if pager.filter != nil && !pager.filter(item) {
+					continue
+				}
+
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if pager.Done() || (pager.maxPages > 0 && pager.fetchedPages >= pager.maxPages) {
+				return
+			}
+
+			page, err := pager.fetch()
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			pager.page, pager.offset = page, min(pager.skip, len(page))
+			pager.skip = 0
+		}
+	}
+}
+
+// Take returns the next limit items, only fetching as many pages as needed.
+// When filtering, at most maxFilteredPages further pages are scanned unless
+// the pager has a lower scan limit.
+func (pager *Pager[T]) Take(limit int) ([]T, error) {
+	items := []T{}
+	if limit <= 0 {
+		return items, nil
+	}
+
+	if pager.filter != nil {
+		pager.maxPages = pager.fetchedPages + cmp.Or(pager.scanLimit, maxFilteredPages)
+		defer func() {
+			pager.maxPages = 0
+		}()
+	}
+
+	for item, err := range pager.Items() {
+		if err != nil {
+			return items, err
+		}
+
+		items = append(items, item)
+		if len(items) == limit {
+			break
+		}
+	}
+
+	return items, nil
+}
@@ -1,12 +1,19 @@
 package circleci
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/jedrw/circlog/config"
 )
 
+// ErrNoPipeline is returned when no pipeline matches, which may only be because
+// it has not been created yet
+var ErrNoPipeline = errors.New("no pipeline found")
+
 type PipelineError struct {
 	Type    string `json:"type"`
 	Message string `json:"message"`
@@ -23,10 +30,6 @@ type PipelineTrigger struct {
 	Actor      Actor     `json:"actor"`
 }
 
-type PipelineTriggerParameters struct {
-	PropertyName interface{} `json:"property_name"`
-}
-
 type Commit struct {
 	Body    string `json:"body"`
 	Subject string `json:"subject"`
@@ -37,7 +40,7 @@ type Vcs struct {
 	TargetRepositoryUrl string `json:"target_repository_url"`
 	Branch              string `json:"branch"`
 	ReviewId            string `json:"review_id"`
-	ReviewUrl           string `json:"revew_url"`
+	ReviewUrl           string `json:"review_url"`
 	Revision            string `json:"revision"`
 	Tag                 string `json:"tag"`
 	Commit              Commit `json:"commit"`
@@ -45,25 +48,124 @@ type Vcs struct {
 }
 
 type Pipeline struct {
-	Id                string                    `json:"id"`
-	Errors            []PipelineError           `json:"errors"`
-	ProjectSlug       string                    `json:"project_slug"`
-	UpdatedAt         time.Time                 `json:"updated_at"`
-	Number            int                       `json:"number"`
-	TriggerParameters PipelineTriggerParameters `json:"trigger_parameters"`
-	State             string                    `json:"state"`
-	CreatedAt         time.Time                 `json:"created_at"`
-	Trigger           PipelineTrigger           `json:"trigger"`
-	Vcs               Vcs                       `json:"vcs"`
+	Id                string          `json:"id"`
+	Errors            []PipelineError `json:"errors"`
+	ProjectSlug       string          `json:"project_slug"`
+	UpdatedAt         time.Time       `json:"updated_at"`
+	Number            int             `json:"number"`
+	TriggerParameters map[string]any  `json:"trigger_parameters"`
+	State             string          `json:"state"`
+	CreatedAt         time.Time       `json:"created_at"`
+	Trigger           PipelineTrigger `json:"trigger"`
+	Vcs               Vcs             `json:"vcs"`
+}
+
+// PipelineConfig is the config of a pipeline as written and as compiled, along
+// with the setup config of pipelines using dynamic config
+type PipelineConfig struct {
+	Source              string `json:"source"`
+	Compiled            string `json:"compiled"`
+	SetupConfig         string `json:"setup-config"`
+	CompiledSetupConfig string `json:"compiled-setup-config"`
+}
+
+func GetPipelineByNumber(config config.CirclogConfig, number int) (Pipeline, error) {
+	endpoint := fmt.Sprintf("%s/project/%s/pipeline/%d", CIRCLECI_ENDPOINT_V2, config.ProjectSlugV2(), number)
+
+	body, err := getRequest(endpoint, config.Token, nil)
+	if err != nil {
+		return Pipeline{}, err
+	}
+
+	var pipeline Pipeline
+	err = json.Unmarshal(body, &pipeline)
+	if err != nil {
+		return Pipeline{}, err
+	}
+
+	return pipeline, err
+}
+
+// GetPipelineByRevision returns the most recent pipeline for the commit
+// revision, which may be abbreviated. As the API cannot filter by revision at
+// most maxPages pages are searched, or a default number when maxPages is 0.
+func GetPipelineByRevision(config config.CirclogConfig, revision string, maxPages int) (Pipeline, error) {
+	pager := ProjectPipelinesPager(config, PipelineFilter{Revision: revision}, "")
+	pager.scanLimit = maxPages
+
+	pipelines, err := pager.Take(1)
+	if err != nil {
+		return Pipeline{}, err
+	}
+
+	if len(pipelines) == 0 {
+		return Pipeline{}, fmt.Errorf("%w for revision %s", ErrNoPipeline, revision)
+	}
+
+	return pipelines[0], nil
 }
 
 func GetProjectPipelines(config config.CirclogConfig, numPages int, nextPageToken string) ([]Pipeline, string, error) {
-	url := fmt.Sprintf("%s/project/%s/pipeline", CIRCLECI_ENDPOINT_V2, config.ProjectSlugV2())
+	pager := ProjectPipelinesPager(config, PipelineFilter{}, nextPageToken)
+
+	pipelines, err := pager.Pages(numPages)
+	if err != nil {
+		return []Pipeline{}, pager.NextPageToken(), err
+	}
+
+	return pipelines, pager.NextPageToken(), err
+}
+
+func ProjectPipelinesPager(config config.CirclogConfig, filter PipelineFilter, nextPageToken string) *Pager[Pipeline] {
+	endpoint := fmt.Sprintf("%s/project/%s/pipeline", CIRCLECI_ENDPOINT_V2, config.ProjectSlugV2())
+	params := url.Values{}
+
+	var match func(Pipeline) bool
+	if filter.clientSide() {
+		match = filter.Match
+	}
+
+	if filter.Mine {
+		endpoint = fmt.Sprintf("%s/mine", endpoint)
+
+		// The mine endpoint does not support filtering by branch
+		if config.Branch != "" {
+			branch := config.Branch
+			match = func(pipeline Pipeline) bool {
+				return pipeline.Vcs.Branch == branch && filter.Match(pipeline)
+			}
+		}
+	} else if config.Branch != "" {
+		params.Set("branch", config.Branch)
+	}
+
+	pager := NewPager(endpoint, config, params, nextPageToken, match)
+
+	// Pipelines are listed newest first so none after one older than Since can
+	// match
+	if !filter.Since.IsZero() {
+		since := filter.Since
+		pager.stop = func(pipeline Pipeline) bool {
+			return pipeline.CreatedAt.Before(since)
+		}
+	}
+
+	return pager
+}
+
+func GetPipelineConfig(config config.CirclogConfig, pipelineId string) (PipelineConfig, error) {
+	endpoint := fmt.Sprintf("%s/pipeline/%s/config", CIRCLECI_ENDPOINT_V2, pipelineId)
+
+	body, err := getRequest(endpoint, config.Token, nil)
+	if err != nil {
+		return PipelineConfig{}, err
+	}
 
-	pipelines, nextPageToken, err := MakeRequest[Pipeline](url, config, numPages, nextPageToken)
+	var pipelineConfig PipelineConfig
+	err = json.Unmarshal(body, &pipelineConfig)
 	if err != nil {
-		return []Pipeline{}, nextPageToken, err
+		return PipelineConfig{}, err
 	}
 
-	return pipelines, nextPageToken, err
+	return pipelineConfig, err
 }
@@ -3,15 +3,20 @@ package circleci
 import (
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"time"
 
 	"github.com/jedrw/circlog/config"
 )
 
 type JobDetails struct {
-	Steps []Step `json:"steps"`
+	Steps     []Step       `json:"steps"`
+	Workflows JobWorkflows `json:"workflows"`
+}
+
+type JobWorkflows struct {
+	WorkflowId   string `json:"workflow_id"`
+	WorkflowName string `json:"workflow_name"`
+	JobName      string `json:"job_name"`
 }
 
 type Step struct {
@@ -43,23 +48,13 @@ type Action struct {
 }
 
 func GetJobSteps(config config.CirclogConfig, jobNumber int64) (JobDetails, error) {
-	url := fmt.Sprintf("%s/project/%s/%d", CIRCLECI_ENDPOINT_V1, config.ProjectSlugV1(), jobNumber)
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return JobDetails{}, err
-	}
+	endpoint := fmt.Sprintf("%s/project/%s/%d", CIRCLECI_ENDPOINT_V1, config.ProjectSlugV1(), jobNumber)
 
-	req.Header.Add("Circle-Token", config.Token)
-
-	res, err := http.DefaultClient.Do(req)
+	body, err := getRequest(endpoint, config.Token, nil)
 	if err != nil {
 		return JobDetails{}, err
 	}
 
-	defer res.Body.Close()
-	body, _ := io.ReadAll(res.Body)
-
 	var jobDetails JobDetails
 	err = json.Unmarshal(body, &jobDetails)
 	if err != nil {
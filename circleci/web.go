@@ -0,0 +1,125 @@
+package circleci
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jedrw/circlog/config"
+)
+
+const CIRCLECI_WEB_URL = "https://app.circleci.com"
+
+func webUrl(config config.CirclogConfig) string {
+	if config.WebUrl != "" {
+		return strings.TrimSuffix(config.WebUrl, "/")
+	}
+
+	return CIRCLECI_WEB_URL
+}
+
+// ProjectWebUrl returns the URL of the project's pipelines in the CircleCI web
+// app, limited to the configured branch if there is one
+func ProjectWebUrl(config config.CirclogConfig) string {
+	projectUrl := fmt.Sprintf("%s/pipelines/%s", webUrl(config), config.ProjectSlugV1())
+	if config.Branch != "" {
+		projectUrl += "?" + url.Values{"branch": {config.Branch}}.Encode()
+	}
+
+	return projectUrl
+}
+
+// PipelineWebUrl returns the URL of a pipeline in the CircleCI web app
+func PipelineWebUrl(config config.CirclogConfig, pipelineNumber int) string {
+	return fmt.Sprintf("%s/pipelines/%s/%d", webUrl(config), config.ProjectSlugV1(), pipelineNumber)
+}
+
+func WorkflowWebUrl(config config.CirclogConfig, pipelineNumber int, workflowId string) string {
+	return fmt.Sprintf("%s/workflows/%s", PipelineWebUrl(config, pipelineNumber), workflowId)
+}
+
+func JobWebUrl(config config.CirclogConfig, pipelineNumber int, workflowId string, jobNumber int64) string {
+	return fmt.Sprintf("%s/jobs/%d", WorkflowWebUrl(config, pipelineNumber, workflowId), jobNumber)
+}
+
+// GetJobWebUrl looks up the workflow and pipeline of a job in order to return
+// its URL
+func GetJobWebUrl(config config.CirclogConfig, jobNumber int64) (string, error) {
+	jobDetails, err := GetJobSteps(config, jobNumber)
+	if err != nil {
+		return "", err
+	}
+
+	workflow, err := GetWorkflow(config, jobDetails.Workflows.WorkflowId)
+	if err != nil {
+		return "", err
+	}
+
+	return JobWebUrl(config, workflow.PipelineNumber, workflow.Id, jobNumber), nil
+}
+
+// WebLocation is the project, pipeline, workflow and job a URL of the CircleCI
+// web app points to, as far as it goes
+type WebLocation struct {
+	Vcs        string
+	Org        string
+	Project    string
+	Pipeline   int
+	WorkflowId string
+	JobNumber  int64
+}
+
+// ParseWebUrl parses URLs such as those returned by JobWebUrl, on any host
+func ParseWebUrl(webUrl string) (WebLocation, error) {
+	parsed, err := url.Parse(webUrl)
+	if err != nil {
+		return WebLocation{}, err
+	}
+
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	start := slices.Index(segments, "pipelines")
+	if start < 0 || len(segments) < start+4 {
+		return WebLocation{}, fmt.Errorf("not a CircleCI project URL: %s", webUrl)
+	}
+
+	segments = segments[start+1:]
+	vcs := segments[0]
+	if _, ok := config.VCSV1ToV2[vcs]; !ok {
+		for v1, v2 := range config.VCSV1ToV2 {
+			if v2 == vcs {
+				vcs = v1
+			}
+		}
+	}
+
+	if _, ok := config.VCSV1ToV2[vcs]; !ok {
+		return WebLocation{}, fmt.Errorf("unsupported VCS %q in %s", segments[0], webUrl)
+	}
+
+	location := WebLocation{Vcs: vcs, Org: segments[1], Project: segments[2]}
+	if len(segments) == 3 {
+		return location, nil
+	}
+
+	location.Pipeline, err = strconv.Atoi(segments[3])
+	if err != nil {
+		return WebLocation{}, fmt.Errorf("invalid pipeline number in %s", webUrl)
+	}
+
+	for i := 4; i+1 < len(segments); i += 2 {
+		switch segments[i] {
+		case "workflows":
+			location.WorkflowId = segments[i+1]
+
+		case "jobs":
+			location.JobNumber, err = strconv.ParseInt(segments[i+1], 10, 64)
+			if err != nil {
+				return WebLocation{}, fmt.Errorf("invalid job number in %s", webUrl)
+			}
+		}
+	}
+
+	return location, nil
+}
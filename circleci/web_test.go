@@ -0,0 +1,127 @@
+package circleci
+
+import (
+	"testing"
+
+	"github.com/jedrw/circlog/config"
+)
+
+func TestParseWebUrl(t *testing.T) {
+	tests := []struct {
+		name         string
+		webUrl       string
+		wantLocation WebLocation
+		wantErr      bool
+	}{
+		{
+			name:         "project",
+			webUrl:       "https://app.circleci.com/pipelines/github/jedrw/circlog",
+			wantLocation: WebLocation{Vcs: "github", Org: "jedrw", Project: "circlog"},
+		},
+		{
+			name:         "project with branch query and trailing slash",
+			webUrl:       "https://app.circleci.com/pipelines/github/jedrw/circlog/?branch=main",
+			wantLocation: WebLocation{Vcs: "github", Org: "jedrw", Project: "circlog"},
+		},
+		{
+			name:         "pipeline",
+			webUrl:       "https://app.circleci.com/pipelines/github/jedrw/circlog/4567",
+			wantLocation: WebLocation{Vcs: "github", Org: "jedrw", Project: "circlog", Pipeline: 4567},
+		},
+		{
+			name:   "job",
+			webUrl: "https://app.circleci.com/pipelines/github/jedrw/circlog/4567/workflows/0b9c4a2e-1f3d-4c5b-8a6e-7d8f9a0b1c2d/jobs/8910",
+			wantLocation: WebLocation{
+				Vcs:        "github",
+				Org:        "jedrw",
+				Project:    "circlog",
+				Pipeline:   4567,
+				WorkflowId: "0b9c4a2e-1f3d-4c5b-8a6e-7d8f9a0b1c2d",
+				JobNumber:  8910,
+			},
+		},
+		{
+			name:         "job page with a trailing section",
+			webUrl:       "https://app.circleci.com/pipelines/github/jedrw/circlog/4567/workflows/abc/jobs/8910/parallel-runs/0",
+			wantLocation: WebLocation{Vcs: "github", Org: "jedrw", Project: "circlog", Pipeline: 4567, WorkflowId: "abc", JobNumber: 8910},
+		},
+		{
+			name:         "v2 VCS slug",
+			webUrl:       "https://app.circleci.com/pipelines/gh/jedrw/circlog/1",
+			wantLocation: WebLocation{Vcs: "github", Org: "jedrw", Project: "circlog", Pipeline: 1},
+		},
+		{
+			name:         "self-hosted with a path prefix",
+			webUrl:       "https://ci.example.com/circleci/pipelines/bitbucket/team/app/12/workflows/abc",
+			wantLocation: WebLocation{Vcs: "bitbucket", Org: "team", Project: "app", Pipeline: 12, WorkflowId: "abc"},
+		},
+		{
+			name:    "not a pipelines URL",
+			webUrl:  "https://app.circleci.com/settings/project/github/jedrw/circlog",
+			wantErr: true,
+		},
+		{
+			name:    "missing project",
+			webUrl:  "https://app.circleci.com/pipelines/github/jedrw",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported VCS",
+			webUrl:  "https://app.circleci.com/pipelines/circleci/jedrw/circlog",
+			wantErr: true,
+		},
+		{
+			name:    "invalid pipeline number",
+			webUrl:  "https://app.circleci.com/pipelines/github/jedrw/circlog/latest",
+			wantErr: true,
+		},
+		{
+			name:    "invalid job number",
+			webUrl:  "https://app.circleci.com/pipelines/github/jedrw/circlog/1/workflows/abc/jobs/last",
+			wantErr: true,
+		},
+		{
+			name:    "unparseable URL",
+			webUrl:  "https://app.circleci.com/%zz",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			location, err := ParseWebUrl(test.webUrl)
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %+v", location)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if location != test.wantLocation {
+				t.Errorf("expected %+v, got %+v", test.wantLocation, location)
+			}
+		})
+	}
+}
+
+func TestParseJobWebUrl(t *testing.T) {
+	for _, webUrl := range []string{"", "https://ci.example.com/", "https://ci.example.com/circleci"} {
+		t.Run(webUrl, func(t *testing.T) {
+			want := WebLocation{Vcs: "gitlab", Org: "group", Project: "app", Pipeline: 3, WorkflowId: "abc", JobNumber: 42}
+
+			location, err := ParseWebUrl(JobWebUrl(config.CirclogConfig{Vcs: "gitlab", Org: "group", Project: "app", WebUrl: webUrl}, 3, "abc", 42))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if location != want {
+				t.Errorf("expected %+v, got %+v", want, location)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package circleci
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -22,13 +23,73 @@ type Workflow struct {
 	StoppedAt      time.Time `json:"stopped_at"`
 }
 
+func (workflow Workflow) Duration() time.Duration {
+	if workflow.Status == RUNNING {
+		return time.Since(workflow.CreatedAt).Round(time.Millisecond)
+	}
+
+	return workflow.StoppedAt.Sub(workflow.CreatedAt).Round(time.Millisecond)
+}
+
+// PipelineStatus summarises the status of a pipeline's workflows. It is empty
+// when there are no workflows and running until they have all finished.
+func PipelineStatus(workflows []Workflow) string {
+	if len(workflows) == 0 {
+		return ""
+	}
+
+	status := SUCCESS
+	for _, workflow := range workflows {
+		switch {
+		case !IsTerminalStatus(workflow.Status):
+			return RUNNING
+		case workflow.Status == FAILED:
+			status = FAILED
+		case (workflow.Status == ERROR || workflow.Status == UNAUTHORIZED) && status != FAILED:
+			status = ERROR
+		case (workflow.Status == CANCELED || workflow.Status == NOT_RUN) && status == SUCCESS:
+			status = CANCELED
+		}
+	}
+
+	return status
+}
+
+func GetWorkflow(config config.CirclogConfig, workflowId string) (Workflow, error) {
+	endpoint := fmt.Sprintf("%s/workflow/%s", CIRCLECI_ENDPOINT_V2, workflowId)
+
+	body, err := getRequest(endpoint, config.Token, nil)
+	if err != nil {
+		return Workflow{}, err
+	}
+
+	var workflow Workflow
+	err = json.Unmarshal(body, &workflow)
+	if err != nil {
+		return Workflow{}, err
+	}
+
+	return workflow, err
+}
+
 func GetPipelineWorkflows(config config.CirclogConfig, pipelineId string, numPages int, nextPageToken string) ([]Workflow, string, error) {
-	url := fmt.Sprintf("%s/pipeline/%s/workflow", CIRCLECI_ENDPOINT_V2, pipelineId)
+	pager := PipelineWorkflowsPager(config, pipelineId, WorkflowFilter{}, nextPageToken)
 
-	workflows, nextPageToken, err := MakeRequest[Workflow](url, config, numPages, nextPageToken)
+	workflows, err := pager.Pages(numPages)
 	if err != nil {
-		return []Workflow{}, nextPageToken, err
+		return []Workflow{}, pager.NextPageToken(), err
+	}
+
+	return workflows, pager.NextPageToken(), err
+}
+
+func PipelineWorkflowsPager(config config.CirclogConfig, pipelineId string, filter WorkflowFilter, nextPageToken string) *Pager[Workflow] {
+	endpoint := fmt.Sprintf("%s/pipeline/%s/workflow", CIRCLECI_ENDPOINT_V2, pipelineId)
+
+	var match func(Workflow) bool
+	if filter.clientSide() {
+		match = filter.Match
 	}
 
-	return workflows, nextPageToken, err
+	return NewPager(endpoint, config, nil, nextPageToken, match)
 }
@@ -0,0 +1,96 @@
+package clipboard
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+const (
+	AUTO    = "auto"
+	OSC52   = "osc52"
+	XCLIP   = "xclip"
+	WL_COPY = "wl-copy"
+	PBCOPY  = "pbcopy"
+)
+
+type Clipboard interface {
+	Copy(text string) error
+}
+
+// Osc52 asks the terminal to set the clipboard, which works over SSH and
+// within tmux
+type Osc52 struct {
+	Out io.Writer
+}
+
+func (osc52 Osc52) Copy(text string) error {
+	sequence := fmt.Sprintf("\033]52;c;%s\a", base64.StdEncoding.EncodeToString([]byte(text)))
+	if os.Getenv("TMUX") != "" {
+		sequence = fmt.Sprintf("\033Ptmux;%s\033\\", strings.ReplaceAll(sequence, "\033", "\033\033"))
+	}
+
+	_, err := io.WriteString(osc52.Out, sequence)
+
+	return err
+}
+
+// Command copies by piping the text to a clipboard program
+type Command struct {
+	Name string
+	Args []string
+}
+
+func (command Command) Copy(text string) error {
+	cmd := exec.Command(command.Name, command.Args...)
+	cmd.Stdin = strings.NewReader(text)
+
+	return cmd.Run()
+}
+
+var commands = map[string]Command{
+	XCLIP:   {Name: "xclip", Args: []string{"-selection", "clipboard"}},
+	WL_COPY: {Name: "wl-copy"},
+	PBCOPY:  {Name: "pbcopy"},
+}
+
+func New(name string, out io.Writer) (Clipboard, error) {
+	switch name {
+	case AUTO, "":
+		return detect(out), nil
+	case OSC52:
+		return Osc52{Out: out}, nil
+	case XCLIP, WL_COPY, PBCOPY:
+		return commands[name], nil
+	}
+
+	return nil, fmt.Errorf("invalid clipboard %q, valid values are ['%s', '%s', '%s', '%s', '%s']", name, AUTO, OSC52, XCLIP, WL_COPY, PBCOPY)
+}
+
+// detect prefers OSC 52 over SSH, where clipboard programs would set the
+// remote clipboard, otherwise the first clipboard program available
+func detect(out io.Writer) Clipboard {
+	if os.Getenv("SSH_TTY") != "" || os.Getenv("SSH_CONNECTION") != "" {
+		return Osc52{Out: out}
+	}
+
+	candidates := []string{PBCOPY}
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		candidates = append(candidates, WL_COPY)
+	}
+
+	if os.Getenv("DISPLAY") != "" {
+		candidates = append(candidates, XCLIP)
+	}
+
+	for _, name := range candidates {
+		if _, err := exec.LookPath(commands[name].Name); err == nil {
+			return commands[name]
+		}
+	}
+
+	return Osc52{Out: out}
+}
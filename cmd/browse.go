@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jedrw/circlog/browser"
+	"github.com/jedrw/circlog/circleci"
+	"github.com/spf13/cobra"
+)
+
+var browseCmd = &cobra.Command{
+	Use:   "browse [project]",
+	Short: "Open a project, pipeline, workflow or job in the CircleCI web app",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pipelineNumber, _ := cmd.Flags().GetInt("pipeline")
+		workflowId, _ := cmd.Flags().GetString("workflow")
+		jobNumber, _ := cmd.Flags().GetInt64("job")
+		printUrl, _ := cmd.Flags().GetBool("print")
+
+		var webUrl string
+		switch {
+		case jobNumber != 0:
+			var err error
+			webUrl, err = circleci.GetJobWebUrl(cmdConfig, jobNumber)
+			if err != nil {
+				return err
+			}
+
+		case workflowId != "":
+			workflow, err := circleci.GetWorkflow(cmdConfig, workflowId)
+			if err != nil {
+				return err
+			}
+
+			webUrl = circleci.WorkflowWebUrl(cmdConfig, workflow.PipelineNumber, workflow.Id)
+
+		case pipelineNumber != 0:
+			webUrl = circleci.PipelineWebUrl(cmdConfig, pipelineNumber)
+
+		default:
+			webUrl = circleci.ProjectWebUrl(cmdConfig)
+		}
+
+		if printUrl {
+			fmt.Println(webUrl)
+
+			return nil
+		}
+
+		return browser.Open(webUrl)
+	},
+}
+
+func init() {
+	browseCmd.Flags().StringP("branch", "b", "", "Branch to show the pipelines of")
+	browseCmd.Flags().Int("pipeline", 0, "Pipeline number")
+	browseCmd.Flags().StringP("workflow", "w", "", "Workflow ID")
+	browseCmd.Flags().Int64P("job", "j", 0, "Job number")
+	browseCmd.Flags().Bool("print", false, "Print the URL instead of opening it")
+
+	browseCmd.MarkFlagsMutuallyExclusive("pipeline", "workflow", "job")
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jedrw/circlog/circleci"
+	"github.com/spf13/cobra"
+)
+
+var configOfCmd = &cobra.Command{
+	Use:   "config-of [project]",
+	Short: "Print the config of a pipeline",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pipelineNumber, _ := cmd.Flags().GetInt("pipeline")
+		compiled, _ := cmd.Flags().GetBool("compiled")
+		setup, _ := cmd.Flags().GetBool("setup")
+
+		pipeline, err := resolvePipeline(pipelineNumber)
+		if err != nil {
+			return err
+		}
+
+		pipelineConfig, err := circleci.GetPipelineConfig(cmdConfig, pipeline.Id)
+		if err != nil {
+			return err
+		}
+
+		var text string
+		switch {
+		case setup && compiled:
+			text = pipelineConfig.CompiledSetupConfig
+		case setup:
+			text = pipelineConfig.SetupConfig
+		case compiled:
+			text = pipelineConfig.Compiled
+		default:
+			text = pipelineConfig.Source
+		}
+
+		if text == "" {
+			return fmt.Errorf("pipeline %d has no such config", pipeline.Number)
+		}
+
+		fmt.Println(text)
+
+		return nil
+	},
+}
+
+func init() {
+	configOfCmd.Flags().StringP("branch", "b", "", "Branch, defaults to the branch checked out in the working directory")
+	configOfCmd.Flags().Int("pipeline", 0, "Pipeline number, defaults to the latest pipeline on the branch")
+	configOfCmd.Flags().Bool("compiled", false, "Print the config as compiled by CircleCI, with orbs expanded")
+	configOfCmd.Flags().Bool("setup", false, "Print the setup config of a pipeline using dynamic config")
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import "errors"
+
+const (
+	exitError    = 1
+	exitFailed   = 2
+	exitErrored  = 3
+	exitCanceled = 4
+	exitTimeout  = 5
+)
+
+type exitCodeError struct {
+	code int
+	err  error
+}
+
+func (e *exitCodeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitCodeError) Unwrap() error {
+	return e.err
+}
+
+// ExitCode returns the code the process should exit with for err
+func ExitCode(err error) int {
+	var exitErr *exitCodeError
+	if errors.As(err, &exitErr) {
+		return exitErr.code
+	}
+
+	return exitError
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jedrw/circlog/circleci"
+)
+
+var timeFlagLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+func parseTimeFlag(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err == nil {
+		return time.Now().Add(-duration), nil
+	}
+
+	for _, layout := range timeFlagLayouts {
+		t, err := time.ParseInLocation(layout, value, time.Local)
+		if err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time %q, expected a duration (24h), date (2006-01-02) or RFC3339 timestamp", value)
+}
+
+func parseNameFlag(pattern string) (string, error) {
+	err := circleci.ValidateNamePattern(pattern)
+	if err != nil {
+		return "", fmt.Errorf("invalid name pattern %q: %w", pattern, err)
+	}
+
+	return pattern, nil
+}
@@ -10,9 +10,22 @@ var jobsCmd = &cobra.Command{
 	Short: "Get the jobs for a workflow",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		numPages, _ := cmd.Flags().GetInt("number-pages")
+		pageToken, _ := cmd.Flags().GetString("page-token")
 		workflowId, _ := cmd.Flags().GetString("workflow-id")
-		workflowJobs, _, err := circleci.GetWorkflowJobs(cmdConfig, workflowId, numPages, "")
+		name, _ := cmd.Flags().GetString("name")
+		status, _ := cmd.Flags().GetString("status")
+
+		name, err := parseNameFlag(name)
+		if err != nil {
+			return err
+		}
+
+		filter := circleci.JobFilter{
+			Name:   name,
+			Status: status,
+		}
+
+		workflowJobs, err := collectItems(cmd, circleci.WorkflowJobsPager(cmdConfig, workflowId, filter, pageToken))
 		if err != nil {
 			return err
 		}
@@ -23,5 +36,8 @@ var jobsCmd = &cobra.Command{
 
 func init() {
 	jobsCmd.Flags().StringP("workflow-id", "w", "", "Workflow Id (required)")
+	jobsCmd.Flags().String("name", "", "Job name, may be a glob e.g. 'test-*'")
+	jobsCmd.Flags().String("status", "", "Job status e.g. success, running, failed")
+	addPaginationFlags(jobsCmd)
 	jobsCmd.MarkFlagRequired("workflow-id")
 }
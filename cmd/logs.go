@@ -2,8 +2,10 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/jedrw/circlog/circleci"
+	"github.com/jedrw/circlog/logscan"
 	"github.com/spf13/cobra"
 )
 
@@ -16,12 +18,42 @@ var logsCmd = &cobra.Command{
 		stepNumber, _ := cmd.Flags().GetInt64("step-number")
 		stepIndex, _ := cmd.Flags().GetInt64("step-index")
 		allocationId, _ := cmd.Flags().GetString("allocation-id")
+		grep, _ := cmd.Flags().GetString("grep")
+		context, _ := cmd.Flags().GetInt("context")
+
+		switch {
+		case cmd.Flags().Changed("context") && grep == "":
+			return fmt.Errorf("--context requires --grep")
+		case context < 0:
+			return fmt.Errorf("--context must not be negative")
+		}
+
 		logs, err := circleci.GetStepLogs(cmdConfig, jobNumber, stepNumber, stepIndex, allocationId)
 		if err != nil {
 			return err
 		}
 
-		fmt.Print(logs)
+		if grep == "" {
+			fmt.Print(logs)
+
+			return nil
+		}
+
+		pattern, err := logscan.CompilePattern(grep, true)
+		if err != nil {
+			return fmt.Errorf("invalid --grep pattern: %w", err)
+		}
+
+		lines := strings.Split(strings.TrimSuffix(logs, "\n"), "\n")
+		for i, group := range logscan.Grep(lines, pattern, context) {
+			if i != 0 && context != 0 {
+				fmt.Println("--")
+			}
+
+			for _, n := range group {
+				fmt.Println(lines[n])
+			}
+		}
 
 		return nil
 	},
@@ -32,6 +64,8 @@ func init() {
 	logsCmd.Flags().Int64P("step-number", "s", 0, "Step Number (required)")
 	logsCmd.Flags().Int64P("step-index", "i", 0, "Step Index (required)")
 	logsCmd.Flags().StringP("allocation-id", "a", "", "Allocation Id (required)")
+	logsCmd.Flags().String("grep", "", "Only print lines matching this regex")
+	logsCmd.Flags().Int("context", 0, "Lines of context to print around each match, requires --grep")
 
 	logsCmd.MarkFlagRequired("job-number")
 	logsCmd.MarkFlagRequired("step-number")
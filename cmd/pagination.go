@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jedrw/circlog/circleci"
+	"github.com/spf13/cobra"
+)
+
+func addPaginationFlags(cmd *cobra.Command) {
+	cmd.Flags().Int("limit", 0, "Number of items to return, fetching further pages only as needed. Overrides --number-pages")
+	cmd.Flags().String("page-token", "", "Resume from a page token printed by a previous invocation")
+}
+
+// collectItems fetches items from pager according to the --limit and
+// --number-pages flags, printing a token to resume from to stderr when more
+// items remain
+func collectItems[T circleci.ResponseType](cmd *cobra.Command, pager *circleci.Pager[T]) ([]T, error) {
+	numPages, _ := cmd.Flags().GetInt("number-pages")
+	limit, _ := cmd.Flags().GetInt("limit")
+
+	var items []T
+	var err error
+	if limit > 0 {
+		items, err = pager.Take(limit)
+	} else {
+		items, err = pager.Pages(numPages)
+	}
+
+	if err != nil {
+		return items, err
+	}
+
+	cursor := pager.Cursor()
+	if cursor != "" {
+		fmt.Fprintf(os.Stderr, "Next page token: %s\n", cursor)
+	}
+
+	return items, nil
+}
@@ -10,8 +10,49 @@ var pipelinesCmd = &cobra.Command{
 	Short: "Get the pipelines for a project",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		numPages, _ := cmd.Flags().GetInt("number-pages")
-		projectPipelines, _, err := circleci.GetProjectPipelines(cmdConfig, numPages, "")
+		pageToken, _ := cmd.Flags().GetString("page-token")
+		status, _ := cmd.Flags().GetString("status")
+		since, _ := cmd.Flags().GetString("since")
+		until, _ := cmd.Flags().GetString("until")
+		triggerType, _ := cmd.Flags().GetString("trigger-type")
+		actor, _ := cmd.Flags().GetString("actor")
+		revision, _ := cmd.Flags().GetString("revision")
+		mine, _ := cmd.Flags().GetBool("mine")
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		filter := circleci.PipelineFilter{
+			Status:      status,
+			TriggerType: triggerType,
+			Actor:       actor,
+			Revision:    revision,
+			Mine:        mine,
+		}
+
+		var err error
+		filter.Since, err = parseTimeFlag(since)
+		if err != nil {
+			return err
+		}
+
+		filter.Until, err = parseTimeFlag(until)
+		if err != nil {
+			return err
+		}
+
+		pager := circleci.ProjectPipelinesPager(cmdConfig, filter, pageToken)
+
+		// The pipelines for a commit are created together so there is no need
+		// to look beyond the first page containing any of them
+		if revision != "" && limit == 0 {
+			projectPipelines, err := pager.NextMatchingPage()
+			if err != nil {
+				return err
+			}
+
+			return outputJson(projectPipelines)
+		}
+
+		projectPipelines, err := collectItems(cmd, pager)
 		if err != nil {
 			return err
 		}
@@ -22,4 +63,12 @@ var pipelinesCmd = &cobra.Command{
 
 func init() {
 	pipelinesCmd.Flags().StringP("branch", "b", "", "Branch")
+	pipelinesCmd.Flags().String("status", "", "Pipeline state e.g. created, errored, setup, pending")
+	pipelinesCmd.Flags().String("since", "", "Only pipelines created at or after this time. A duration (24h), date (2006-01-02) or RFC3339 timestamp")
+	pipelinesCmd.Flags().String("until", "", "Only pipelines created at or before this time. A duration (24h), date (2006-01-02) or RFC3339 timestamp")
+	pipelinesCmd.Flags().String("trigger-type", "", "Trigger type e.g. webhook, api, schedule")
+	pipelinesCmd.Flags().String("actor", "", "Login of the user that triggered the pipeline")
+	pipelinesCmd.Flags().String("revision", "", "Commit SHA, may be abbreviated")
+	pipelinesCmd.Flags().Bool("mine", false, "Only pipelines triggered by the current user")
+	addPaginationFlags(pipelinesCmd)
 }
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jedrw/circlog/circleci"
+	"github.com/jedrw/circlog/config"
+	"github.com/jedrw/circlog/git"
+)
+
+// branchConfig returns the config with the branch checked out in the working
+// directory selected, unless a branch was already selected
+func branchConfig() config.CirclogConfig {
+	config := cmdConfig
+	if config.Branch == "" {
+		branch, err := git.CurrentBranch()
+		if err == nil {
+			config.Branch = branch
+		}
+	}
+
+	return config
+}
+
+// resolvePipeline returns the pipeline with the given number, or the latest
+// pipeline on the selected branch when number is 0. Without a selected branch
+// the branch checked out in the working directory is used.
+func resolvePipeline(number int) (circleci.Pipeline, error) {
+	if number != 0 {
+		return circleci.GetPipelineByNumber(cmdConfig, number)
+	}
+
+	config := branchConfig()
+	pipelines, err := circleci.ProjectPipelinesPager(config, circleci.PipelineFilter{}, "").Take(1)
+	if err != nil {
+		return circleci.Pipeline{}, err
+	}
+
+	if len(pipelines) == 0 {
+		if config.Branch != "" {
+			return circleci.Pipeline{}, fmt.Errorf("%w for branch %s", circleci.ErrNoPipeline, config.Branch)
+		}
+
+		return circleci.Pipeline{}, circleci.ErrNoPipeline
+	}
+
+	return pipelines[0], nil
+}
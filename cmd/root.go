@@ -1,6 +1,11 @@
 package cmd
 
 import (
+	"cmp"
+	"fmt"
+	"strings"
+
+	"github.com/jedrw/circlog/circleci"
 	"github.com/jedrw/circlog/config"
 	"github.com/jedrw/circlog/tui"
 	"github.com/spf13/cobra"
@@ -8,8 +13,11 @@ import (
 
 var cmdConfig config.CirclogConfig
 
+// webLocation is where a CircleCI URL given in place of the project points to
+var webLocation circleci.WebLocation
+
 var rootCmd = &cobra.Command{
-	Use:   "circlog [project]",
+	Use:   "circlog [project | url]",
 	Short: "CircleCI CLI tool",
 	Args:  cobra.MaximumNArgs(1),
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
@@ -25,6 +33,18 @@ var rootCmd = &cobra.Command{
 		org, _ := cmd.Flags().GetString("org")
 		branch, _ := cmd.Flags().GetString("branch")
 
+		if strings.Contains(project, "://") {
+			var err error
+			webLocation, err = circleci.ParseWebUrl(project)
+			if err != nil {
+				return err
+			}
+
+			project = webLocation.Project
+			vcs = cmp.Or(vcs, webLocation.Vcs)
+			org = cmp.Or(org, webLocation.Org)
+		}
+
 		var err error
 		cmdConfig, err = config.NewConfig(project, vcs, org, branch)
 
@@ -33,6 +53,40 @@ var rootCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		circlogTui := tui.NewCirclogTui(cmdConfig)
 
+		resume, _ := cmd.Flags().GetBool("resume")
+		if resume {
+			err := circlogTui.Resume()
+			if err != nil {
+				return err
+			}
+		}
+
+		pipeline, _ := cmd.Flags().GetInt("pipeline")
+		workflow, _ := cmd.Flags().GetString("workflow")
+		job, _ := cmd.Flags().GetString("job")
+		follow, _ := cmd.Flags().GetBool("follow")
+
+		pipeline = cmp.Or(pipeline, webLocation.Pipeline)
+		workflow = cmp.Or(workflow, webLocation.WorkflowId)
+		if job == "" && webLocation.JobNumber != 0 {
+			job = fmt.Sprint(webLocation.JobNumber)
+		}
+
+		switch {
+		case pipeline != 0 && cmdConfig.Project == "":
+			return fmt.Errorf("a project is required to open a pipeline")
+		case workflow != "" && pipeline == 0:
+			return fmt.Errorf("--workflow requires --pipeline")
+		case job != "" && workflow == "":
+			return fmt.Errorf("--job requires --workflow")
+		case follow && job == "":
+			return fmt.Errorf("--follow requires --job")
+		}
+
+		if pipeline != 0 {
+			circlogTui.Open(pipeline, workflow, job, follow)
+		}
+
 		return circlogTui.Run()
 	},
 }
@@ -46,6 +100,12 @@ func init() {
 	rootCmd.PersistentFlags().StringP("org", "o", "", "Organisation")
 	rootCmd.PersistentFlags().IntP("number-pages", "n", 1, "Number of pages to return. -1 to return everything, this may take a long time if the project has many pipelines")
 	rootCmd.Flags().StringP("branch", "b", "", "Branch")
+	rootCmd.Flags().Bool("resume", false, "Reopen the TUI where it was last left")
+	rootCmd.Flags().Int("pipeline", 0, "Pipeline number to open")
+	rootCmd.Flags().String("workflow", "", "Name or ID of a workflow of the pipeline to open")
+	rootCmd.Flags().String("job", "", "Name or number of a job of the workflow to open")
+	rootCmd.Flags().Bool("follow", false, "Follow the logs of the job")
+	rootCmd.MarkFlagsMutuallyExclusive("resume", "pipeline")
 
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(pipelinesCmd)
@@ -53,5 +113,10 @@ func init() {
 	rootCmd.AddCommand(jobsCmd)
 	rootCmd.AddCommand(stepsCmd)
 	rootCmd.AddCommand(logsCmd)
+	rootCmd.AddCommand(statusCmd)
+	rootCmd.AddCommand(waitCmd)
+	rootCmd.AddCommand(watchCmd)
+	rootCmd.AddCommand(browseCmd)
+	rootCmd.AddCommand(configOfCmd)
 	cobra.EnableCommandSorting = false
 }
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jedrw/circlog/circleci"
+	"github.com/jedrw/circlog/theme"
+	"github.com/spf13/cobra"
+)
+
+type jobStatus struct {
+	Job       circleci.Job `json:"job"`
+	Duration  string       `json:"duration"`
+	DependsOn []string     `json:"depends_on"`
+}
+
+type workflowStatus struct {
+	Workflow circleci.Workflow `json:"workflow"`
+	Duration string            `json:"duration"`
+	Jobs     []jobStatus       `json:"jobs"`
+}
+
+type pipelineStatus struct {
+	Pipeline  circleci.Pipeline `json:"pipeline"`
+	Workflows []workflowStatus  `json:"workflows"`
+}
+
+var statusCmd = &cobra.Command{
+	Use:   "status [project]",
+	Short: "Print the workflows and jobs of a pipeline as a tree",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pipelineNumber, _ := cmd.Flags().GetInt("pipeline")
+		asJson, _ := cmd.Flags().GetBool("json")
+
+		pipeline, err := resolvePipeline(pipelineNumber)
+		if err != nil {
+			return err
+		}
+
+		status, err := getPipelineStatus(pipeline)
+		if err != nil {
+			return err
+		}
+
+		if asJson {
+			return outputJson(status)
+		}
+
+		colours, err := theme.New(cmdConfig.Theme, cmdConfig.Colours)
+		if err != nil {
+			return err
+		}
+
+		colours.NoColor = colours.NoColor || !isTerminal(os.Stdout)
+		fmt.Print(renderStatusTree(status, colours))
+
+		return nil
+	},
+}
+
+func init() {
+	statusCmd.Flags().StringP("branch", "b", "", "Branch, defaults to the branch checked out in the working directory")
+	statusCmd.Flags().Int("pipeline", 0, "Pipeline number, defaults to the latest pipeline on the branch")
+	statusCmd.Flags().Bool("json", false, "Output JSON instead of a tree")
+}
+
+func getPipelineStatus(pipeline circleci.Pipeline) (pipelineStatus, error) {
+	status := pipelineStatus{
+		Pipeline:  pipeline,
+		Workflows: []workflowStatus{},
+	}
+
+	workflows, _, err := circleci.GetPipelineWorkflows(cmdConfig, pipeline.Id, -1, "")
+	if err != nil {
+		return status, err
+	}
+
+	for _, workflow := range workflows {
+		jobs, _, err := circleci.GetWorkflowJobs(cmdConfig, workflow.Id, -1, "")
+		if err != nil {
+			return status, err
+		}
+
+		workflowStatus := workflowStatus{
+			Workflow: workflow,
+			Duration: workflow.Duration().String(),
+			Jobs:     []jobStatus{},
+		}
+
+		for _, job := range jobs {
+			dependsOn := circleci.NamedJobDependencies(job, jobs)
+			if dependsOn == nil {
+				dependsOn = []string{}
+			}
+
+			workflowStatus.Jobs = append(workflowStatus.Jobs, jobStatus{
+				Job:       job,
+				Duration:  job.Duration().String(),
+				DependsOn: dependsOn,
+			})
+		}
+
+		status.Workflows = append(status.Workflows, workflowStatus)
+	}
+
+	return status, nil
+}
+
+func renderStatusTree(status pipelineStatus, colours theme.Theme) string {
+	var tree strings.Builder
+	paint := colours.Ansi
+
+	pipeline := status.Pipeline
+	branchOrTag := pipeline.Vcs.Branch
+	if branchOrTag == "" {
+		branchOrTag = pipeline.Vcs.Tag
+	}
+
+	fmt.Fprintln(&tree, paint(pipeline.State, fmt.Sprintf("Pipeline %d  %s  %s", pipeline.Number, branchOrTag, pipeline.State)))
+
+	for i, workflow := range status.Workflows {
+		branch, indent := "├── ", "│   "
+		if i == len(status.Workflows)-1 {
+			branch, indent = "└── ", "    "
+		}
+
+		fmt.Fprintf(&tree, "%s%s\n", branch, paint(workflow.Workflow.Status, fmt.Sprintf("%s  %s  %s", workflow.Workflow.Name, workflow.Workflow.Status, workflow.Duration)))
+
+		for j, job := range workflow.Jobs {
+			jobBranch := "├── "
+			if j == len(workflow.Jobs)-1 {
+				jobBranch = "└── "
+			}
+
+			line := fmt.Sprintf("%s  %s  %s", job.Job.Name, job.Job.Status, job.Duration)
+			if len(job.DependsOn) != 0 {
+				line = fmt.Sprintf("%s  (requires %s)", line, strings.Join(job.DependsOn, ", "))
+			}
+
+			fmt.Fprintf(&tree, "%s%s%s\n", indent, jobBranch, paint(job.Job.Status, line))
+		}
+	}
+
+	return tree.String()
+}
+
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
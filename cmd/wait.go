@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jedrw/circlog/circleci"
+	"github.com/spf13/cobra"
+)
+
+var waitCmd = &cobra.Command{
+	Use:   "wait [project]",
+	Short: "Wait for a pipeline or workflow to finish",
+	Long: `Wait for every workflow of a pipeline, or a single workflow, to finish.
+
+The exit code reflects the outcome:
+  0  success
+  2  a workflow or job failed
+  3  the pipeline or a workflow errored, or the pipeline has no workflows
+  4  a workflow was canceled
+  5  timed out`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pipelineNumber, _ := cmd.Flags().GetInt("pipeline")
+		workflowId, _ := cmd.Flags().GetString("workflow")
+		commit, _ := cmd.Flags().GetString("commit")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		interval, _ := cmd.Flags().GetDuration("interval")
+		failFast, _ := cmd.Flags().GetBool("fail-fast")
+
+		if interval <= 0 {
+			return fmt.Errorf("--interval must be positive")
+		}
+
+		cmd.SilenceUsage = true
+
+		var getWorkflows func() ([]circleci.Workflow, error)
+		if workflowId != "" {
+			getWorkflows = func() ([]circleci.Workflow, error) {
+				workflow, err := circleci.GetWorkflow(cmdConfig, workflowId)
+				return []circleci.Workflow{workflow}, err
+			}
+		} else {
+			findPipeline := func() (circleci.Pipeline, error) {
+				if commit != "" {
+					return circleci.GetPipelineByRevision(branchConfig(), commit, revisionPages)
+				}
+
+				return resolvePipeline(pipelineNumber)
+			}
+
+			// The pipeline is looked for on every poll until found, as it may
+			// not have been created yet when waiting straight after a push
+			var pipeline *circleci.Pipeline
+			notFoundLogged := false
+			emptyPolls := 0
+			getWorkflows = func() ([]circleci.Workflow, error) {
+				if pipeline == nil {
+					found, err := findPipeline()
+					if errors.Is(err, circleci.ErrNoPipeline) {
+						if !notFoundLogged {
+							notFoundLogged = true
+							logProgress("%s, waiting for it to be created", err)
+						}
+
+						return []circleci.Workflow{}, nil
+					} else if err != nil {
+						return []circleci.Workflow{}, err
+					}
+
+					if found.State == circleci.ERRORED {
+						return []circleci.Workflow{}, &exitCodeError{exitErrored, fmt.Errorf("pipeline %d errored", found.Number)}
+					}
+
+					fmt.Fprintf(os.Stderr, "Waiting for pipeline %d (%s)\n", found.Number, found.Vcs.Revision)
+					pipeline = &found
+				}
+
+				workflows, _, err := circleci.GetPipelineWorkflows(cmdConfig, pipeline.Id, -1, "")
+				if err != nil || len(workflows) != 0 {
+					return workflows, err
+				}
+
+				// A pipeline without workflows has errored, or has had all of them
+				// filtered out once it is created. They may still be on their way
+				// when it is first seen created, so it is given one more poll.
+				current, err := circleci.GetPipelineByNumber(cmdConfig, pipeline.Number)
+				if err != nil {
+					return []circleci.Workflow{}, err
+				}
+
+				switch current.State {
+				case circleci.ERRORED:
+					return []circleci.Workflow{}, &exitCodeError{exitErrored, fmt.Errorf("pipeline %d errored", current.Number)}
+
+				case circleci.CREATED:
+					emptyPolls++
+					if emptyPolls > 1 {
+						return []circleci.Workflow{}, &exitCodeError{exitErrored, fmt.Errorf("pipeline %d has no workflows", current.Number)}
+					}
+				}
+
+				return workflows, nil
+			}
+		}
+
+		return waitForWorkflows(getWorkflows, timeout, interval, failFast)
+	},
+}
+
+// revisionPages is how many pages of the branch are searched for the pipeline
+// of --commit on each poll
+const revisionPages = 3
+
+func init() {
+	waitCmd.Flags().Int("pipeline", 0, "Pipeline number, defaults to the latest pipeline on the branch checked out in the working directory")
+	waitCmd.Flags().String("workflow", "", "Workflow Id")
+	waitCmd.Flags().String("commit", "", "Commit SHA of the pipeline")
+	waitCmd.Flags().Duration("timeout", 30*time.Minute, "Give up after this long, 0 to wait indefinitely")
+	waitCmd.Flags().Duration("interval", 10*time.Second, "Time between polls")
+	waitCmd.Flags().Bool("fail-fast", false, "Stop as soon as a job fails")
+	waitCmd.MarkFlagsMutuallyExclusive("pipeline", "workflow", "commit")
+}
+
+func waitForWorkflows(getWorkflows func() ([]circleci.Workflow, error), timeout time.Duration, interval time.Duration, failFast bool) error {
+	deadline := time.Now().Add(timeout)
+	lastStatus := map[string]string{}
+
+	for {
+		workflows, err := getWorkflows()
+		if err != nil {
+			return err
+		}
+
+		finished := len(workflows) != 0
+		for _, workflow := range workflows {
+			if lastStatus[workflow.Id] != workflow.Status {
+				lastStatus[workflow.Id] = workflow.Status
+				logProgress("workflow %s %s", workflow.Name, workflow.Status)
+			}
+
+			if !circleci.IsTerminalStatus(workflow.Status) {
+				finished = false
+			}
+		}
+
+		if finished {
+			return workflowsOutcome(workflows)
+		}
+
+		if failFast {
+			err := checkFailedJobs(workflows, lastStatus)
+			if err != nil {
+				return err
+			}
+		}
+
+		if timeout > 0 && time.Now().After(deadline) {
+			return &exitCodeError{exitTimeout, fmt.Errorf("timed out after %s", timeout)}
+		}
+
+		time.Sleep(interval)
+	}
+}
+
+// checkFailedJobs returns an error as soon as any workflow or job has failed
+func checkFailedJobs(workflows []circleci.Workflow, lastStatus map[string]string) error {
+	for _, workflow := range workflows {
+		if circleci.IsTerminalStatus(workflow.Status) {
+			if workflow.Status != circleci.SUCCESS {
+				return workflowsOutcome([]circleci.Workflow{workflow})
+			}
+
+			continue
+		}
+
+		jobs, _, err := circleci.GetWorkflowJobs(cmdConfig, workflow.Id, -1, "")
+		if err != nil {
+			return err
+		}
+
+		for _, job := range jobs {
+			if lastStatus[job.Id] != job.Status {
+				lastStatus[job.Id] = job.Status
+				logProgress("job %s/%s %s", workflow.Name, job.Name, job.Status)
+			}
+
+			if job.Status == circleci.FAILED {
+				return &exitCodeError{exitFailed, fmt.Errorf("job %s in workflow %s failed", job.Name, workflow.Name)}
+			}
+		}
+	}
+
+	return nil
+}
+
+func workflowsOutcome(workflows []circleci.Workflow) error {
+	var failed, errored, canceled []string
+	for _, workflow := range workflows {
+		switch workflow.Status {
+		case circleci.FAILED:
+			failed = append(failed, workflow.Name)
+		case circleci.ERROR, circleci.UNAUTHORIZED:
+			errored = append(errored, workflow.Name)
+		case circleci.CANCELED, circleci.NOT_RUN:
+			canceled = append(canceled, workflow.Name)
+		}
+	}
+
+	switch {
+	case len(failed) != 0:
+		return &exitCodeError{exitFailed, fmt.Errorf("workflows failed: %s", strings.Join(failed, ", "))}
+	case len(errored) != 0:
+		return &exitCodeError{exitErrored, fmt.Errorf("workflows errored: %s", strings.Join(errored, ", "))}
+	case len(canceled) != 0:
+		return &exitCodeError{exitCanceled, fmt.Errorf("workflows canceled: %s", strings.Join(canceled, ", "))}
+	}
+
+	return nil
+}
+
+func logProgress(format string, a ...any) {
+	fmt.Fprintf(os.Stderr, "%s  %s\n", time.Now().Format(time.TimeOnly), fmt.Sprintf(format, a...))
+}
@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/jedrw/circlog/circleci"
+	"github.com/jedrw/circlog/config"
+	"github.com/jedrw/circlog/notify"
+	"github.com/spf13/cobra"
+)
+
+type hooks struct {
+	onFail    notify.Notifier
+	onSuccess notify.Notifier
+	webhook   notify.Notifier
+}
+
+var watchCmd = &cobra.Command{
+	Use:   "watch [project]",
+	Short: "Run hooks when workflows and jobs finish",
+	Long: `Poll the most recent pipelines of a project and run hooks when their workflows
+and jobs finish. Hooks fire once per status transition, the last seen status
+of every workflow and job is saved so restarting does not fire them again.
+
+Commands are run with sh -c and the transition described by CIRCLOG_*
+environment variables. Webhooks receive the same information as a JSON POST.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		onFail, _ := cmd.Flags().GetString("on-fail")
+		onSuccess, _ := cmd.Flags().GetString("on-success")
+		webhook, _ := cmd.Flags().GetString("webhook")
+		interval, _ := cmd.Flags().GetDuration("interval")
+		numPipelines, _ := cmd.Flags().GetInt("pipelines")
+
+		if onFail == "" && onSuccess == "" && webhook == "" {
+			return fmt.Errorf("at least one of --on-fail, --on-success or --webhook is required")
+		}
+
+		if interval <= 0 {
+			return fmt.Errorf("--interval must be positive")
+		}
+
+		var watchHooks hooks
+		if onFail != "" {
+			watchHooks.onFail = notify.Command{Command: onFail}
+		}
+
+		if onSuccess != "" {
+			watchHooks.onSuccess = notify.Command{Command: onSuccess}
+		}
+
+		if webhook != "" {
+			watchHooks.webhook = notify.Webhook{Url: webhook}
+		}
+
+		trackerPath, err := config.FilePath(fmt.Sprintf("watch-%s-%s-%s.json", cmdConfig.Vcs, cmdConfig.Org, cmdConfig.Project))
+		if err != nil {
+			return err
+		}
+
+		tracker, err := notify.LoadTracker(trackerPath)
+		if err != nil {
+			return err
+		}
+
+		cmd.SilenceUsage = true
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			err := pollTransitions(tracker, watchHooks, numPipelines)
+			if err != nil {
+				logProgress("poll failed: %s", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+	},
+}
+
+func init() {
+	watchCmd.Flags().StringP("branch", "b", "", "Branch")
+	watchCmd.Flags().String("on-fail", "", "Command to run when a workflow or job fails")
+	watchCmd.Flags().String("on-success", "", "Command to run when a workflow or job succeeds")
+	watchCmd.Flags().String("webhook", "", "URL to POST a JSON payload to when a workflow or job finishes")
+	watchCmd.Flags().Duration("interval", 30*time.Second, "Time between polls")
+	watchCmd.Flags().Int("pipelines", 5, "Number of recent pipelines to watch")
+}
+
+type observation struct {
+	key          string
+	notification notify.Notification
+}
+
+func pollTransitions(tracker *notify.Tracker, watchHooks hooks, numPipelines int) error {
+	observations, err := observeStatuses(numPipelines)
+	if err != nil {
+		return err
+	}
+
+	// Statuses are only recorded once a poll has seen everything, otherwise a
+	// failed request could cause finished items to be forgotten and fire again
+	seen := map[string]bool{}
+	var notifications []notify.Notification
+	for _, observation := range observations {
+		seen[observation.key] = true
+		notification := observation.notification
+
+		previous, changed := tracker.Observe(observation.key, notification.Status)
+		if !changed {
+			continue
+		}
+
+		logProgress("%s %s %s", notification.Kind, notification.Name, notification.Status)
+		if circleci.IsTerminalStatus(notification.Status) {
+			notification.PreviousStatus = previous
+			notifications = append(notifications, notification)
+		}
+	}
+
+	tracker.Forget(seen)
+	tracker.Seed()
+
+	err = tracker.Save()
+	if err != nil {
+		return err
+	}
+
+	for _, notification := range notifications {
+		watchHooks.fire(notification)
+	}
+
+	return nil
+}
+
+func observeStatuses(numPipelines int) ([]observation, error) {
+	var observations []observation
+
+	pipelines, err := circleci.ProjectPipelinesPager(cmdConfig, circleci.PipelineFilter{}, "").Take(numPipelines)
+	if err != nil {
+		return observations, err
+	}
+
+	for _, pipeline := range pipelines {
+		workflows, _, err := circleci.GetPipelineWorkflows(cmdConfig, pipeline.Id, -1, "")
+		if err != nil {
+			return observations, err
+		}
+
+		for _, workflow := range workflows {
+			notification := notify.Notification{
+				Project:        cmdConfig.Project,
+				Kind:           "workflow",
+				Name:           workflow.Name,
+				Status:         workflow.Status,
+				Duration:       workflow.Duration(),
+				PipelineId:     pipeline.Id,
+				PipelineNumber: pipeline.Number,
+				Branch:         pipeline.Vcs.Branch,
+				Revision:       pipeline.Vcs.Revision,
+				WorkflowId:     workflow.Id,
+				WorkflowName:   workflow.Name,
+			}
+
+			jobs, _, err := circleci.GetWorkflowJobs(cmdConfig, workflow.Id, -1, "")
+			if err != nil {
+				return observations, err
+			}
+
+			for _, job := range jobs {
+				jobNotification := notification
+				jobNotification.Kind = "job"
+				jobNotification.Name = job.Name
+				jobNotification.Status = job.Status
+				jobNotification.Duration = job.Duration()
+				jobNotification.JobNumber = job.JobNumber
+				jobNotification.JobName = job.Name
+
+				observations = append(observations, observation{
+					key:          fmt.Sprintf("job:%s:%s", workflow.Id, job.Name),
+					notification: jobNotification,
+				})
+			}
+
+			observations = append(observations, observation{
+				key:          fmt.Sprintf("workflow:%s", workflow.Id),
+				notification: notification,
+			})
+		}
+	}
+
+	return observations, nil
+}
+
+func (watchHooks hooks) fire(notification notify.Notification) {
+	var notifiers []notify.Notifier
+	switch notification.Status {
+	case circleci.SUCCESS:
+		notifiers = append(notifiers, watchHooks.onSuccess)
+	case circleci.FAILED, circleci.ERROR, circleci.UNAUTHORIZED:
+		notifiers = append(notifiers, watchHooks.onFail)
+	}
+
+	notifiers = append(notifiers, watchHooks.webhook)
+
+	for _, notifier := range notifiers {
+		if notifier == nil {
+			continue
+		}
+
+		err := notifier.Notify(notification)
+		if err != nil {
+			logProgress("hook for %s %s failed: %s", notification.Kind, notification.Name, err)
+		}
+	}
+}
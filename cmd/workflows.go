@@ -10,9 +10,22 @@ var workflowsCmd = &cobra.Command{
 	Short: "Get the workflows for a pipeline",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		numPages, _ := cmd.Flags().GetInt("number-pages")
+		pageToken, _ := cmd.Flags().GetString("page-token")
 		pipelineId, _ := cmd.Flags().GetString("pipeline-id")
-		pipelineWorkflows, _, err := circleci.GetPipelineWorkflows(cmdConfig, pipelineId, numPages, "")
+		name, _ := cmd.Flags().GetString("name")
+		status, _ := cmd.Flags().GetString("status")
+
+		name, err := parseNameFlag(name)
+		if err != nil {
+			return err
+		}
+
+		filter := circleci.WorkflowFilter{
+			Name:   name,
+			Status: status,
+		}
+
+		pipelineWorkflows, err := collectItems(cmd, circleci.PipelineWorkflowsPager(cmdConfig, pipelineId, filter, pageToken))
 		if err != nil {
 			return err
 		}
@@ -23,5 +36,8 @@ var workflowsCmd = &cobra.Command{
 
 func init() {
 	workflowsCmd.Flags().StringP("pipeline-id", "l", "", "Pipeline Id (required)")
+	workflowsCmd.Flags().String("name", "", "Workflow name, may be a glob e.g. 'build-*'")
+	workflowsCmd.Flags().String("status", "", "Workflow status e.g. success, running, failed")
+	addPaginationFlags(workflowsCmd)
 	workflowsCmd.MarkFlagRequired("pipeline-id")
 }
@@ -30,6 +30,46 @@ type CirclogConfig struct {
 	Project string
 	Token   string
 	Vcs     string `yaml:"vcs"`
+
+	Notifier      string `yaml:"notifier"`
+	NotifyCommand string `yaml:"notify_command"`
+
+	ErrorPatterns []string `yaml:"error_patterns"`
+
+	Clipboard string `yaml:"clipboard"`
+	WebUrl    string `yaml:"web_url"`
+
+	Theme   string            `yaml:"theme"`
+	Colours map[string]string `yaml:"colours"`
+
+	Keys    map[string]KeyList `yaml:"keys"`
+	VimKeys bool               `yaml:"vim_keys"`
+
+	Layout    string         `yaml:"layout"`
+	PaneSizes map[string]int `yaml:"pane_sizes"`
+
+	PipelineColumns []string `yaml:"pipeline_columns"`
+}
+
+// KeyList is the keys bound to an action, given as either a single key or a
+// list of them
+type KeyList []string
+
+func (keys *KeyList) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var key string
+	if err := unmarshal(&key); err == nil {
+		*keys = KeyList{key}
+		return nil
+	}
+
+	var list []string
+	if err := unmarshal(&list); err != nil {
+		return err
+	}
+
+	*keys = list
+
+	return nil
 }
 
 func GetToken() (string, bool, error) {
@@ -99,6 +139,17 @@ func ensureConfigDir() (string, error) {
 	return circlogConfigDir, err
 }
 
+// FilePath returns the path of name within the circlog config directory,
+// creating the directory if needed
+func FilePath(name string) (string, error) {
+	circlogConfigDir, err := ensureConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(circlogConfigDir, name), nil
+}
+
 func ensureStateFile() (string, error) {
 	circlogConfigDir, err := ensureConfigDir()
 	if err != nil {
@@ -120,13 +171,45 @@ func ensureStateFile() (string, error) {
 	return circlogConfigFile, err
 }
 
+// updateState writes newState to the config file, preserving any other
+// settings in it
 func updateState(stateFilePath string, newState circlogState) error {
-	configYaml, err := yaml.Marshal(&newState)
+	return updateConfigFile(stateFilePath, yaml.MapSlice{
+		{Key: "organisation", Value: newState.Organisation},
+		{Key: "vcs", Value: newState.Vcs},
+	})
+}
+
+func updateConfigFile(configFilePath string, settings yaml.MapSlice) error {
+	b, err := os.ReadFile(configFilePath)
+	if err != nil {
+		return err
+	}
+
+	var configFile yaml.MapSlice
+	err = yaml.Unmarshal(b, &configFile)
+	if err != nil {
+		return fmt.Errorf("could not parse %s", configFilePath)
+	}
+
+SETTINGS:
+	for _, setting := range settings {
+		for i := range configFile {
+			if configFile[i].Key == setting.Key {
+				configFile[i].Value = setting.Value
+				continue SETTINGS
+			}
+		}
+
+		configFile = append(configFile, setting)
+	}
+
+	configYaml, err := yaml.Marshal(&configFile)
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(stateFilePath, configYaml, 0644)
+	return os.WriteFile(configFilePath, configYaml, 0644)
 }
 
 func loadConfig(stateFilePath string) (CirclogConfig, error) {
@@ -0,0 +1,50 @@
+package git
+
+import (
+	"os/exec"
+	"strings"
+)
+
+func run(args ...string) (string, error) {
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// CurrentBranch returns the branch checked out in the working directory, or an
+// empty string when HEAD is detached
+func CurrentBranch() (string, error) {
+	branch, err := run("rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return "", err
+	}
+
+	if branch == "HEAD" {
+		return "", nil
+	}
+
+	return branch, nil
+}
+
+// HeadRevision returns the SHA of the commit checked out in the working
+// directory
+func HeadRevision() (string, error) {
+	return run("rev-parse", "HEAD")
+}
+
+// RepositoryName returns the name of the repository the origin remote points
+// to, e.g. "circlog" for git@github.com:jedrw/circlog.git
+func RepositoryName() (string, error) {
+	remoteUrl, err := run("remote", "get-url", "origin")
+	if err != nil {
+		return "", err
+	}
+
+	remoteUrl = strings.TrimSuffix(strings.TrimSuffix(remoteUrl, "/"), ".git")
+	name := remoteUrl[strings.LastIndexAny(remoteUrl, "/:")+1:]
+
+	return name, nil
+}
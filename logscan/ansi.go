@@ -0,0 +1,15 @@
+package logscan
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Matches CSI sequences such as colours, OSC sequences such as hyperlinks and
+// any other two byte escape sequences
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)|\x1b[@-Z\\-_]`)
+
+// StripANSI removes ANSI escape sequences and carriage returns from text
+func StripANSI(text string) string {
+	return strings.ReplaceAll(ansiPattern.ReplaceAllString(text, ""), "\r", "")
+}
@@ -0,0 +1,29 @@
+package logscan
+
+import "testing"
+
+func TestStripANSI(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "plain text", text: "plain text", want: "plain text"},
+		{name: "colours", text: "\x1b[1;31merror\x1b[0m: failed", want: "error: failed"},
+		{name: "cursor movement", text: "\x1b[2K\x1b[1Gdone", want: "done"},
+		{name: "hyperlink terminated by BEL", text: "\x1b]8;;https://example.com\x07link\x1b]8;;\x07", want: "link"},
+		{name: "hyperlink terminated by ST", text: "\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\", want: "link"},
+		{name: "two byte sequence", text: "\x1bMup", want: "up"},
+		{name: "carriage returns", text: "50%\r100%\r\n", want: "50%100%\n"},
+		{name: "unterminated sequence is kept", text: "\x1b[31", want: "\x1b[31"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := StripANSI(test.text)
+			if got != test.want {
+				t.Errorf("expected %q, got %q", test.want, got)
+			}
+		})
+	}
+}
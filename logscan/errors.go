@@ -0,0 +1,74 @@
+package logscan
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+)
+
+// DefaultErrorPatterns recognise the lines common tools print when something
+// fails
+var DefaultErrorPatterns = []string{
+	// Go
+	`^\s*--- FAIL: `,
+	`^FAIL\b`,
+	`^panic: `,
+	`^fatal error: `,
+
+	// Node
+	`^npm ERR! `,
+	`^error\s`,
+	`^\s*(\w+)?Error: `,
+	`^\s*● `,
+
+	// Python
+	`^Traceback \(most recent call last\):`,
+	`^\w+(\.\w+)*(Error|Exception): `,
+	`^(FAILED|ERROR) \S`,
+	`^E {3}`,
+
+	// Java
+	`^\[ERROR\] `,
+	`^Exception in thread `,
+	`^\s*Caused by: `,
+	`^FAILURE: `,
+	`^BUILD FAILED`,
+
+	// Compilers reporting file:line:col
+	`^[^\s:]+:\d+:\d+: (fatal )?error\b`,
+	`^[^\s:]+\.go:\d+:\d+: `,
+}
+
+type ErrorRules []*regexp.Regexp
+
+// NewErrorRules compiles the default error patterns along with any extra
+// patterns. Empty patterns are rejected as they would match every line.
+func NewErrorRules(extra []string) (ErrorRules, error) {
+	if slices.Contains(extra, "") {
+		return nil, fmt.Errorf("error_patterns must not contain an empty pattern")
+	}
+
+	var rules ErrorRules
+	for _, pattern := range slices.Concat(DefaultErrorPatterns, extra) {
+		rule, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid error pattern %q: %w", pattern, err)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// Match reports whether line is an error, it should already have had its ANSI
+// escape sequences removed
+func (rules ErrorRules) Match(line string) bool {
+	for _, rule := range rules {
+		if rule.MatchString(line) {
+			return true
+		}
+	}
+
+	return false
+}
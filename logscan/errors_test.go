@@ -0,0 +1,77 @@
+package logscan
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewErrorRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		extra   []string
+		wantErr string
+	}{
+		{name: "defaults only"},
+		{name: "valid extra patterns", extra: []string{"^ERROR: ", `\bdenied\b`}},
+		{name: "invalid extra pattern", extra: []string{"^ok", "(unclosed"}, wantErr: `invalid error pattern "(unclosed"`},
+		{name: "empty extra pattern", extra: []string{"^ok", ""}, wantErr: "error_patterns must not contain an empty pattern"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rules, err := NewErrorRules(test.extra)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", test.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(rules) != len(DefaultErrorPatterns)+len(test.extra) {
+				t.Errorf("expected %d rules, got %d", len(DefaultErrorPatterns)+len(test.extra), len(rules))
+			}
+		})
+	}
+}
+
+func TestErrorRulesMatch(t *testing.T) {
+	rules, err := NewErrorRules([]string{"^ERROR: "})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		line string
+		want bool
+	}{
+		{"--- FAIL: TestParse (0.00s)", true},
+		{"    --- FAIL: TestParse/empty (0.00s)", true},
+		{"FAIL\tgithub.com/jedrw/circlog/circleci\t0.01s", true},
+		{"panic: runtime error: index out of range", true},
+		{"npm ERR! code ELIFECYCLE", true},
+		{"Traceback (most recent call last):", true},
+		{"ValueError: invalid literal", true},
+		{"[ERROR] Failed to execute goal", true},
+		{"main.go:12:5: undefined: foo", true},
+		{"src/app.c:3:1: error: expected ';'", true},
+		{"ERROR: extra pattern", true},
+		{"ok  \tgithub.com/jedrw/circlog/circleci\t0.01s", false},
+		{"--- PASS: TestParse (0.00s)", false},
+		{"no errors found", false},
+		{"FAILURES=0", false},
+		{"", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.line, func(t *testing.T) {
+			if got := rules.Match(test.line); got != test.want {
+				t.Errorf("expected %t, got %t", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package logscan
+
+import "regexp"
+
+// Grep returns groups of consecutive line indices, each containing lines that
+// match pattern along with up to context lines either side of them. Lines are
+// matched with their ANSI escape sequences removed.
+func Grep(lines []string, pattern *regexp.Regexp, context int) [][]int {
+	keep := make([]bool, len(lines))
+	for i, line := range lines {
+		if !pattern.MatchString(StripANSI(line)) {
+			continue
+		}
+
+		for n := max(i-context, 0); n <= min(i+context, len(lines)-1); n++ {
+			keep[n] = true
+		}
+	}
+
+	var groups [][]int
+	var group []int
+	for i, kept := range keep {
+		if kept {
+			group = append(group, i)
+			continue
+		}
+
+		if len(group) != 0 {
+			groups = append(groups, group)
+			group = nil
+		}
+	}
+
+	if len(group) != 0 {
+		groups = append(groups, group)
+	}
+
+	return groups
+}
@@ -0,0 +1,87 @@
+package logscan
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestGrep(t *testing.T) {
+	lines := []string{"a", "b", "match 1", "c", "d", "match 2", "e", "f", "g", "match 3"}
+
+	tests := []struct {
+		name       string
+		lines      []string
+		pattern    string
+		context    int
+		wantGroups [][]int
+	}{
+		{
+			name:       "no matches",
+			lines:      lines,
+			pattern:    "missing",
+			wantGroups: nil,
+		},
+		{
+			name:       "matches without context",
+			lines:      lines,
+			pattern:    "match",
+			wantGroups: [][]int{{2}, {5}, {9}},
+		},
+		{
+			name:       "consecutive matches form one group",
+			lines:      []string{"match", "match", "other", "match"},
+			pattern:    "match",
+			wantGroups: [][]int{{0, 1}, {3}},
+		},
+		{
+			name:       "overlapping context windows are merged",
+			lines:      lines,
+			pattern:    "match [12]",
+			context:    2,
+			wantGroups: [][]int{{0, 1, 2, 3, 4, 5, 6, 7}},
+		},
+		{
+			name:       "adjacent context windows are merged",
+			lines:      lines,
+			pattern:    "match [23]",
+			context:    1,
+			wantGroups: [][]int{{4, 5, 6}, {8, 9}},
+		},
+		{
+			name:       "context is clipped at either end",
+			lines:      []string{"match", "a", "b", "c", "match"},
+			pattern:    "match",
+			context:    1,
+			wantGroups: [][]int{{0, 1}, {3, 4}},
+		},
+		{
+			name:       "context larger than the logs",
+			lines:      []string{"a", "match", "b"},
+			pattern:    "match",
+			context:    10,
+			wantGroups: [][]int{{0, 1, 2}},
+		},
+		{
+			name:       "escape sequences are ignored when matching",
+			lines:      []string{"\x1b[31mfa\x1b[0mil", "ok"},
+			pattern:    "^fail$",
+			wantGroups: [][]int{{0}},
+		},
+		{
+			name:       "no lines",
+			lines:      nil,
+			pattern:    "match",
+			wantGroups: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			groups := Grep(test.lines, regexp.MustCompile(test.pattern), test.context)
+			if !reflect.DeepEqual(groups, test.wantGroups) {
+				t.Errorf("expected groups %v, got %v", test.wantGroups, groups)
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package logscan
+
+import "regexp"
+
+// CompilePattern compiles pattern as a regular expression, or as a literal
+// string when regex is false
+func CompilePattern(pattern string, regex bool) (*regexp.Regexp, error) {
+	if !regex {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+
+	return regexp.Compile(pattern)
+}
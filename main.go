@@ -11,6 +11,6 @@ func main() {
 	err := cmd.Execute()
 	if err != nil {
 		fmt.Println(err)
-		os.Exit(1)
+		os.Exit(cmd.ExitCode(err))
 	}
 }
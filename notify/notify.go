@@ -0,0 +1,213 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const (
+	BELL        = "bell"
+	OSC9        = "osc9"
+	OSC777      = "osc777"
+	NOTIFY_SEND = "notify-send"
+	COMMAND     = "command"
+
+	webhookTimeout = 10 * time.Second
+)
+
+type Notification struct {
+	Project        string        `json:"project"`
+	Kind           string        `json:"kind"`
+	Name           string        `json:"name"`
+	Status         string        `json:"status"`
+	PreviousStatus string        `json:"previous_status,omitempty"`
+	Duration       time.Duration `json:"duration"`
+
+	PipelineId     string `json:"pipeline_id,omitempty"`
+	PipelineNumber int    `json:"pipeline_number,omitempty"`
+	Branch         string `json:"branch,omitempty"`
+	Revision       string `json:"revision,omitempty"`
+	WorkflowId     string `json:"workflow_id,omitempty"`
+	WorkflowName   string `json:"workflow_name,omitempty"`
+	JobNumber      int64  `json:"job_number,omitempty"`
+	JobName        string `json:"job_name,omitempty"`
+}
+
+// MarshalJSON writes the duration as CIRCLOG_DURATION has it, e.g. "1m30s",
+// rather than in nanoseconds
+func (notification Notification) MarshalJSON() ([]byte, error) {
+	type plain Notification
+
+	return json.Marshal(struct {
+		plain
+		Duration string `json:"duration"`
+	}{plain(notification), notification.Duration.String()})
+}
+
+func (notification Notification) Title() string {
+	return fmt.Sprintf("circlog: %s %s %s", notification.Kind, notification.Name, notification.Status)
+}
+
+func (notification Notification) Body() string {
+	return fmt.Sprintf("%s %s %s after %s", notification.Project, notification.Name, notification.Status, notification.Duration)
+}
+
+type Notifier interface {
+	Notify(notification Notification) error
+}
+
+// Bell rings the terminal bell
+type Bell struct {
+	Out io.Writer
+}
+
+func (bell Bell) Notify(_ Notification) error {
+	_, err := fmt.Fprint(bell.Out, "\a")
+
+	return err
+}
+
+// Osc9 sends a desktop notification with the OSC 9 escape sequence supported
+// by iTerm2, Windows Terminal, kitty and others
+type Osc9 struct {
+	Out io.Writer
+}
+
+func (osc Osc9) Notify(notification Notification) error {
+	_, err := fmt.Fprintf(osc.Out, "\033]9;%s\a", sanitise(notification.Body()))
+
+	return err
+}
+
+// Osc777 sends a desktop notification with the OSC 777 escape sequence
+// supported by urxvt, foot, WezTerm and VTE based terminals
+type Osc777 struct {
+	Out io.Writer
+}
+
+func (osc Osc777) Notify(notification Notification) error {
+	_, err := fmt.Fprintf(osc.Out, "\033]777;notify;%s;%s\a", sanitise(notification.Title()), sanitise(notification.Body()))
+
+	return err
+}
+
+type NotifySend struct{}
+
+func (NotifySend) Notify(notification Notification) error {
+	return exec.Command("notify-send", "--app-name", "circlog", notification.Title(), notification.Body()).Run()
+}
+
+// Command runs a shell command with the notification in CIRCLOG_* environment
+// variables
+type Command struct {
+	Command string
+}
+
+func (command Command) Notify(notification Notification) error {
+	cmd := exec.Command("sh", "-c", command.Command)
+	cmd.Env = append(os.Environ(), notification.Env()...)
+
+	return cmd.Run()
+}
+
+// Env describes the notification as CIRCLOG_* environment variables, those
+// without a value are omitted
+func (notification Notification) Env() []string {
+	env := []string{
+		fmt.Sprintf("CIRCLOG_PROJECT=%s", notification.Project),
+		fmt.Sprintf("CIRCLOG_KIND=%s", notification.Kind),
+		fmt.Sprintf("CIRCLOG_NAME=%s", notification.Name),
+		fmt.Sprintf("CIRCLOG_STATUS=%s", notification.Status),
+		fmt.Sprintf("CIRCLOG_DURATION=%s", notification.Duration),
+	}
+
+	for _, variable := range []struct {
+		name  string
+		value string
+	}{
+		{"CIRCLOG_PREVIOUS_STATUS", notification.PreviousStatus},
+		{"CIRCLOG_PIPELINE_ID", notification.PipelineId},
+		{"CIRCLOG_PIPELINE_NUMBER", formatNonZero(notification.PipelineNumber)},
+		{"CIRCLOG_BRANCH", notification.Branch},
+		{"CIRCLOG_REVISION", notification.Revision},
+		{"CIRCLOG_WORKFLOW_ID", notification.WorkflowId},
+		{"CIRCLOG_WORKFLOW_NAME", notification.WorkflowName},
+		{"CIRCLOG_JOB_NUMBER", formatNonZero(notification.JobNumber)},
+		{"CIRCLOG_JOB_NAME", notification.JobName},
+	} {
+		if variable.value != "" {
+			env = append(env, fmt.Sprintf("%s=%s", variable.name, variable.value))
+		}
+	}
+
+	return env
+}
+
+func formatNonZero[T int | int64](n T) string {
+	if n == 0 {
+		return ""
+	}
+
+	return fmt.Sprint(n)
+}
+
+// Webhook POSTs the notification as JSON to Url
+type Webhook struct {
+	Url string
+}
+
+func (webhook Webhook) Notify(notification Notification) error {
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	client := http.Client{Timeout: webhookTimeout}
+	res, err := client.Post(webhook.Url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+	if res.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("webhook %s responded with %s", webhook.Url, res.Status)
+	}
+
+	return nil
+}
+
+// New returns the notifier called name, escape sequences and the bell are
+// written to out
+func New(name string, command string, out io.Writer) (Notifier, error) {
+	switch name {
+	case BELL, "":
+		return Bell{Out: out}, nil
+	case OSC9:
+		return Osc9{Out: out}, nil
+	case OSC777:
+		return Osc777{Out: out}, nil
+	case NOTIFY_SEND:
+		return NotifySend{}, nil
+	case COMMAND:
+		if command == "" {
+			return nil, fmt.Errorf("notifier %q requires notify_command to be set", COMMAND)
+		}
+
+		return Command{Command: command}, nil
+	}
+
+	return nil, fmt.Errorf("invalid notifier %q, valid values are ['%s', '%s', '%s', '%s', '%s']", name, BELL, OSC9, OSC777, NOTIFY_SEND, COMMAND)
+}
+
+// Escape sequences are terminated by BEL and OSC 777 fields are separated by
+// semicolons, so neither may appear in the text
+func sanitise(text string) string {
+	return strings.NewReplacer("\a", "", "\033", "", ";", ",").Replace(text)
+}
@@ -0,0 +1,35 @@
+package notify
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestNotificationDuration(t *testing.T) {
+	notification := Notification{Project: "circlog", Kind: "job", Name: "test", Status: "success", Duration: 90 * time.Second}
+
+	b, err := json.Marshal(notification)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var payload map[string]any
+	err = json.Unmarshal(b, &payload)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if payload["duration"] != "1m30s" {
+		t.Errorf("expected JSON duration %q, got %v", "1m30s", payload["duration"])
+	}
+
+	if payload["name"] != "test" {
+		t.Errorf("expected JSON name %q, got %v", "test", payload["name"])
+	}
+
+	if !slices.Contains(notification.Env(), "CIRCLOG_DURATION=1m30s") {
+		t.Errorf("expected CIRCLOG_DURATION=1m30s in %v", notification.Env())
+	}
+}
@@ -0,0 +1,76 @@
+package notify
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+)
+
+// A Tracker remembers the last seen status of items between polls, and
+// across restarts when saved to disk
+type Tracker struct {
+	path     string
+	seeded   bool
+	Statuses map[string]string `json:"statuses"`
+}
+
+// LoadTracker reads the tracker saved at path. A tracker that has never been
+// saved reports no transitions until it has been seeded by a first poll.
+func LoadTracker(path string) (*Tracker, error) {
+	tracker := &Tracker{
+		path:     path,
+		Statuses: map[string]string{},
+	}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return tracker, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(b, tracker)
+	if err != nil {
+		return nil, err
+	}
+
+	tracker.seeded = true
+
+	return tracker, nil
+}
+
+// Observe records status for key and returns the previous status and whether
+// this is a transition that has not been reported before
+func (tracker *Tracker) Observe(key string, status string) (string, bool) {
+	previous, seen := tracker.Statuses[key]
+	tracker.Statuses[key] = status
+
+	if !tracker.seeded || (seen && previous == status) {
+		return previous, false
+	}
+
+	return previous, true
+}
+
+// Seed marks the end of the first poll, after which transitions are reported
+func (tracker *Tracker) Seed() {
+	tracker.seeded = true
+}
+
+// Forget drops every key not in keep, so the tracker does not grow forever
+func (tracker *Tracker) Forget(keep map[string]bool) {
+	for key := range tracker.Statuses {
+		if !keep[key] {
+			delete(tracker.Statuses, key)
+		}
+	}
+}
+
+func (tracker *Tracker) Save() error {
+	b, err := json.MarshalIndent(tracker, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(tracker.path, b, 0644)
+}
@@ -0,0 +1,154 @@
+package notify
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type observation struct {
+	key          string
+	status       string
+	wantPrevious string
+	wantChanged  bool
+}
+
+// poll is what the watch command does on every poll, observing every item
+// seen and forgetting the rest before saving
+type poll struct {
+	observations []observation
+	restart      bool
+}
+
+func TestTracker(t *testing.T) {
+	tests := []struct {
+		name  string
+		saved string
+		polls []poll
+	}{
+		{
+			name: "first poll without a saved tracker reports nothing",
+			polls: []poll{
+				{observations: []observation{
+					{key: "a", status: "running"},
+					{key: "b", status: "failed"},
+				}},
+				{observations: []observation{
+					{key: "a", status: "success", wantPrevious: "running", wantChanged: true},
+					{key: "b", status: "failed", wantPrevious: "failed"},
+				}},
+			},
+		},
+		{
+			name:  "loaded tracker reports transitions on the first poll",
+			saved: `{"statuses": {"a": "running", "b": "failed"}}`,
+			polls: []poll{
+				{observations: []observation{
+					{key: "a", status: "success", wantPrevious: "running", wantChanged: true},
+					{key: "b", status: "failed", wantPrevious: "failed"},
+				}},
+			},
+		},
+		{
+			name: "transitions fire once across restarts",
+			polls: []poll{
+				{observations: []observation{{key: "a", status: "running"}}},
+				{restart: true, observations: []observation{
+					{key: "a", status: "success", wantPrevious: "running", wantChanged: true},
+				}},
+				{restart: true, observations: []observation{
+					{key: "a", status: "success", wantPrevious: "success"},
+				}},
+			},
+		},
+		{
+			name: "repeated status is not a transition",
+			polls: []poll{
+				{observations: []observation{{key: "a", status: "running"}}},
+				{observations: []observation{{key: "a", status: "running", wantPrevious: "running"}}},
+				{observations: []observation{{key: "a", status: "failed", wantPrevious: "running", wantChanged: true}}},
+				{observations: []observation{{key: "a", status: "failed", wantPrevious: "failed"}}},
+			},
+		},
+		{
+			name: "new key after the first poll is a transition",
+			polls: []poll{
+				{observations: []observation{{key: "a", status: "running"}}},
+				{observations: []observation{
+					{key: "a", status: "running", wantPrevious: "running"},
+					{key: "b", status: "running", wantChanged: true},
+				}},
+			},
+		},
+		{
+			name: "forgotten key is reported again",
+			polls: []poll{
+				{observations: []observation{
+					{key: "a", status: "success"},
+					{key: "b", status: "success"},
+				}},
+				{observations: []observation{{key: "a", status: "success", wantPrevious: "success"}}},
+				{restart: true, observations: []observation{
+					{key: "a", status: "success", wantPrevious: "success"},
+					{key: "b", status: "success", wantChanged: true},
+				}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "tracker.json")
+			if test.saved != "" {
+				err := os.WriteFile(path, []byte(test.saved), 0644)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			tracker, err := LoadTracker(path)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for i, poll := range test.polls {
+				if poll.restart {
+					tracker, err = LoadTracker(path)
+					if err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+				}
+
+				seen := map[string]bool{}
+				for _, observation := range poll.observations {
+					seen[observation.key] = true
+					previous, changed := tracker.Observe(observation.key, observation.status)
+					if previous != observation.wantPrevious || changed != observation.wantChanged {
+						t.Errorf("poll %d, %s %s: expected (%q, %t), got (%q, %t)", i, observation.key, observation.status, observation.wantPrevious, observation.wantChanged, previous, changed)
+					}
+				}
+
+				tracker.Forget(seen)
+				tracker.Seed()
+
+				err = tracker.Save()
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadTrackerInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tracker.json")
+	err := os.WriteFile(path, []byte("not json"), 0644)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = LoadTracker(path)
+	if err == nil {
+		t.Errorf("expected an error")
+	}
+}
@@ -0,0 +1,217 @@
+package theme
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+const (
+	DARK          = "dark"
+	LIGHT         = "light"
+	HIGH_CONTRAST = "high-contrast"
+
+	// UI elements, the other colours are named after statuses
+	TEXT             = "text"
+	BORDER           = "border"
+	FOCUSED_BORDER   = "focused_border"
+	MUTED            = "muted"
+	ACCENT           = "accent"
+	ERROR_LINE       = "error_line"
+	MATCH            = "match"
+	MATCH_BACKGROUND = "match_background"
+)
+
+var themes = map[string]map[string]string{
+	DARK: {
+		"success":      "darkgreen",
+		"running":      "lightgreen",
+		"not_run":      "gray",
+		"not_running":  "gray",
+		"blocked":      "gray",
+		"failed":       "darkred",
+		"error":        "darkred",
+		"failing":      "pink",
+		"on_hold":      "yellow",
+		"canceled":     "darkred",
+		"unauthorized": "darkred",
+		"created":      "darkgreen",
+		"errored":      "darkred",
+
+		TEXT:             "white",
+		BORDER:           "gray",
+		FOCUSED_BORDER:   "default",
+		MUTED:            "darkgray",
+		ACCENT:           "yellow",
+		ERROR_LINE:       "red",
+		MATCH:            "black",
+		MATCH_BACKGROUND: "yellow",
+	},
+	LIGHT: {
+		"success":      "green",
+		"running":      "teal",
+		"not_run":      "gray",
+		"not_running":  "gray",
+		"blocked":      "gray",
+		"failed":       "maroon",
+		"error":        "maroon",
+		"failing":      "purple",
+		"on_hold":      "olive",
+		"canceled":     "maroon",
+		"unauthorized": "maroon",
+		"created":      "green",
+		"errored":      "maroon",
+
+		TEXT:             "black",
+		BORDER:           "silver",
+		FOCUSED_BORDER:   "black",
+		MUTED:            "gray",
+		ACCENT:           "navy",
+		ERROR_LINE:       "maroon",
+		MATCH:            "black",
+		MATCH_BACKGROUND: "yellow",
+	},
+	HIGH_CONTRAST: {
+		"success":      "lime",
+		"running":      "aqua",
+		"not_run":      "silver",
+		"not_running":  "silver",
+		"blocked":      "silver",
+		"failed":       "red",
+		"error":        "red",
+		"failing":      "fuchsia",
+		"on_hold":      "yellow",
+		"canceled":     "red",
+		"unauthorized": "red",
+		"created":      "lime",
+		"errored":      "red",
+
+		TEXT:             "white",
+		BORDER:           "silver",
+		FOCUSED_BORDER:   "yellow",
+		MUTED:            "silver",
+		ACCENT:           "yellow",
+		ERROR_LINE:       "red",
+		MATCH:            "black",
+		MATCH_BACKGROUND: "aqua",
+	},
+}
+
+type Theme struct {
+	NoColor bool
+	names   map[string]string
+	colours map[string]tcell.Color
+}
+
+// New returns the named theme with any colours in overrides replaced. Colours
+// are given as names, such as "darkgreen", or as "#rrggbb". Setting NO_COLOR
+// disables colour whatever the theme.
+func New(name string, overrides map[string]string) (Theme, error) {
+	if name == "" {
+		name = DARK
+	}
+
+	names, ok := themes[name]
+	if !ok {
+		return Theme{}, fmt.Errorf("invalid theme %q, valid values are ['%s', '%s', '%s']", name, DARK, LIGHT, HIGH_CONTRAST)
+	}
+
+	names = maps.Clone(names)
+	for element, colour := range overrides {
+		if _, ok := names[element]; !ok {
+			return Theme{}, fmt.Errorf("unknown colour %q", element)
+		}
+
+		names[element] = colour
+	}
+
+	theme := Theme{
+		NoColor: os.Getenv("NO_COLOR") != "",
+		names:   names,
+		colours: map[string]tcell.Color{},
+	}
+
+	for element, colourName := range names {
+		colour, err := parseColour(colourName)
+		if err != nil {
+			return Theme{}, fmt.Errorf("invalid colour for %s: %w", element, err)
+		}
+
+		theme.names[element] = strings.ToLower(colourName)
+		theme.colours[element] = colour
+	}
+
+	return theme, nil
+}
+
+func parseColour(name string) (tcell.Color, error) {
+	name = strings.ToLower(name)
+	if name == "default" {
+		return tcell.ColorDefault, nil
+	}
+
+	colour := tcell.GetColor(name)
+	if colour == tcell.ColorDefault {
+		return colour, fmt.Errorf("unknown colour %q", name)
+	}
+
+	return colour, nil
+}
+
+// Colour returns the colour of a status or UI element, which is the terminal's
+// default when colour is disabled
+func (theme Theme) Colour(name string) tcell.Color {
+	if theme.NoColor {
+		return tcell.ColorDefault
+	}
+
+	return theme.colours[name]
+}
+
+// Style returns a style with the colour of name on the default background
+func (theme Theme) Style(name string) tcell.Style {
+	return tcell.StyleDefault.Background(tcell.ColorDefault).Foreground(theme.Colour(name))
+}
+
+// Tag returns a tview colour tag setting the foreground and background to the
+// colours of the named elements, or resetting them when a name is empty
+func (theme Theme) Tag(foreground string, background string, attributes string) string {
+	return fmt.Sprintf("[%s:%s:%s]", theme.tagColour(foreground), theme.tagColour(background), attributes)
+}
+
+func (theme Theme) tagColour(name string) string {
+	colour := theme.Colour(name)
+	if name == "" || colour == tcell.ColorDefault {
+		return "-"
+	}
+
+	return theme.names[name]
+}
+
+// Ansi wraps text in the ANSI escape sequences for the colour of name
+func (theme Theme) Ansi(name string, text string) string {
+	colour := theme.Colour(name)
+	if colour == tcell.ColorDefault {
+		return text
+	}
+
+	var code string
+	// Named colours beyond the 256 colour palette only have RGB values
+	index := colour - tcell.ColorValid
+	switch {
+	case colour&tcell.ColorIsRGB != 0 || index >= 256:
+		r, g, b := colour.RGB()
+		code = fmt.Sprintf("38;2;%d;%d;%d", r, g, b)
+	case index < 8:
+		code = fmt.Sprint(30 + index)
+	case index < 16:
+		code = fmt.Sprint(90 + index - 8)
+	default:
+		code = fmt.Sprintf("38;5;%d", index)
+	}
+
+	return fmt.Sprintf("\033[%sm%s\033[0m", code, text)
+}
@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/jedrw/circlog/browser"
+	"github.com/jedrw/circlog/circleci"
+)
+
+// jobWebUrl returns the URL of job, or of its workflow for approval jobs which
+// have no page of their own
+func (cTui *CirclogTui) jobWebUrl(job circleci.Job) string {
+	if job.JobNumber == 0 {
+		return circleci.WorkflowWebUrl(cTui.config, cTui.state.pipeline.Number, cTui.state.workflow.Id)
+	}
+
+	return circleci.JobWebUrl(cTui.config, cTui.state.pipeline.Number, cTui.state.workflow.Id, job.JobNumber)
+}
+
+// openInBrowser opens webUrl in the background, unless $BROWSER is set in
+// which case the TUI is suspended while it runs as it may be a terminal browser
+func (cTui *CirclogTui) openInBrowser(webUrl string) {
+	if browser.InTerminal() {
+		var err error
+		cTui.app.Suspend(func() {
+			err = browser.Open(webUrl)
+		})
+
+		cTui.browserOpened(webUrl, err)
+		return
+	}
+
+	go func() {
+		err := browser.Command(webUrl).Run()
+		cTui.app.QueueUpdateDraw(func() {
+			cTui.browserOpened(webUrl, err)
+		})
+	}()
+}
+
+func (cTui *CirclogTui) browserOpened(webUrl string, err error) {
+	if err != nil {
+		cTui.flash(fmt.Sprintf("Could not open browser: %s", err))
+	} else {
+		cTui.flash("Opened " + webUrl)
+	}
+}
@@ -1,12 +1,18 @@
 package tui
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/jedrw/circlog/circleci"
+	"github.com/jedrw/circlog/clipboard"
 	"github.com/jedrw/circlog/config"
+	"github.com/jedrw/circlog/logscan"
+	"github.com/jedrw/circlog/notify"
+	"github.com/jedrw/circlog/theme"
 	"github.com/rivo/tview"
 )
 
@@ -23,10 +29,10 @@ type CirclogTui struct {
 	config config.CirclogConfig
 	state  tuiState
 
-	layout   *tview.Flex
-	heading  *tview.Flex
-	upperNav *tview.Flex
-	lowerNav *tview.Flex
+	pages   *tview.Pages
+	layout  *tview.Flex
+	heading *tview.Flex
+	body    *tview.Flex
 
 	info           *tview.Flex
 	projectSelect  *tview.InputField
@@ -41,62 +47,106 @@ type CirclogTui struct {
 	steps     stepsPane
 	logs      logsPane
 
-	colourByStatus map[string]tcell.Color
+	paneLayout paneLayout
+	open       *location
+
+	watchlist *watchlist
+	clipboard clipboard.Clipboard
+	keys      keymap
+
+	modal        string
+	modalContent tview.Primitive
+	modalReturn  tview.Primitive
+	flashTimer   *time.Timer
 }
 
 const refreshInterval = 1 * time.Second
 
-var (
-	colourByStatus = map[string]tcell.Color{
-		"success":      tcell.ColorDarkGreen,
-		"running":      tcell.ColorLightGreen,
-		"not_run":      tcell.ColorGray,
-		"not_running":  tcell.ColorGray,
-		"blocked":      tcell.ColorGray,
-		"failed":       tcell.ColorDarkRed,
-		"error":        tcell.ColorDarkRed,
-		"failing":      tcell.ColorPink,
-		"on_hold":      tcell.ColorYellow,
-		"canceled":     tcell.ColorDarkRed,
-		"unauthorized": tcell.ColorDarkRed,
-
-		"created": tcell.ColorDarkGreen,
-	}
-)
+// colours is the theme the TUI is drawn with
+var colours theme.Theme
 
 func NewCirclogTui(config config.CirclogConfig) CirclogTui {
 	return CirclogTui{
-		config:         config,
-		colourByStatus: colourByStatus,
+		config: config,
 	}
 }
 
 func (cTui *CirclogTui) Run() error {
-	cTui.app = tview.NewApplication()
+	notifier, err := notify.New(cTui.config.Notifier, cTui.config.NotifyCommand, terminalWriter{cTui})
+	if err != nil {
+		return err
+	}
 
-	cTui.initNavLayout()
+	errorRules, err := logscan.NewErrorRules(cTui.config.ErrorPatterns)
+	if err != nil {
+		return err
+	}
 
-	cTui.pipelines = cTui.newPipelinesPane()
-	cTui.upperNav.AddItem(cTui.pipelines.table, 0, 1, false)
+	colours, err = theme.New(cTui.config.Theme, cTui.config.Colours)
+	if err != nil {
+		return err
+	}
 
-	cTui.workflows = cTui.newWorkflowsPane()
-	cTui.upperNav.AddItem(cTui.workflows.table, 0, 1, false)
+	applyTheme()
 
-	cTui.jobs = cTui.newJobsPane()
-	cTui.upperNav.AddItem(cTui.jobs.table, 0, 1, false)
+	cTui.keys, err = newKeymap(cTui.config.Keys)
+	if err != nil {
+		return err
+	}
 
-	cTui.steps = cTui.newStepsPane()
-	cTui.lowerNav.AddItem(cTui.steps.tree, 0, 1, false)
+	err = checkPipelineColumns(cTui.config.PipelineColumns)
+	if err != nil {
+		return err
+	}
 
-	cTui.logs = cTui.newLogsPane()
-	cTui.lowerNav.AddItem(cTui.logs.view, 0, 2, false)
+	cTui.paneLayout, err = loadPaneLayout(cTui.config.Layout, cTui.config.PaneSizes)
+	if err != nil {
+		return err
+	}
+
+	cTui.clipboard, err = clipboard.New(cTui.config.Clipboard, os.Stdout)
+	if err != nil {
+		return err
+	}
+
+	cTui.watchlist = newWatchlist(notifier, func(message string) {
+		cTui.app.QueueUpdateDraw(func() {
+			cTui.flash(message)
+		})
+	})
+	watchCtx, watchCancel := context.WithCancel(context.Background())
+	defer watchCancel()
+	go cTui.watchlist.watch(watchCtx, cTui.config)
+
+	cTui.app = tview.NewApplication().EnableMouse(true)
+	cTui.app.SetMouseCapture(cTui.captureMouse)
+	if cTui.config.VimKeys {
+		cTui.app.SetInputCapture(cTui.translateVimKeys)
+	}
+
+	cTui.initNavLayout()
+	cTui.pages = tview.NewPages().AddPage("main", cTui.layout, true, true)
+
+	cTui.pipelines = cTui.newPipelinesPane(cTui.config.PipelineColumns)
+	cTui.workflows = cTui.newWorkflowsPane()
+	cTui.jobs = cTui.newJobsPane()
+	cTui.steps = cTui.newStepsPane()
+	cTui.logs = cTui.newLogsPane(errorRules)
+	cTui.app.SetBeforeDrawFunc(func(tcell.Screen) bool {
+		cTui.arrangePanes()
+		return false
+	})
 
 	if cTui.config.Project != "" {
-		pipelines, nextPageToken, _ := circleci.GetProjectPipelines(cTui.config, 1, "")
-		cTui.pipelines.populateTable(pipelines, nextPageToken)
-		cTui.app.SetRoot(cTui.layout, true).SetFocus(cTui.pipelines.table)
+		cTui.pipelines.loadFirstPage(cTui)
+		cTui.app.SetRoot(cTui.pages, true).SetFocus(cTui.pipelines.table)
+		if cTui.open != nil {
+			go cTui.drillDown(*cTui.open)
+		} else {
+			go cTui.jumpToHeadPipeline()
+		}
 	} else {
-		cTui.app.SetRoot(cTui.layout, true).SetFocus(cTui.info)
+		cTui.app.SetRoot(cTui.pages, true).SetFocus(cTui.info)
 
 	}
 
@@ -136,14 +186,15 @@ func (cTui *CirclogTui) initNavLayout() {
 
 	cTui.globalControls = tview.NewTextView().SetTextAlign(tview.AlignRight)
 	cTui.globalControls.SetBackgroundColor(tcell.ColorDefault)
-	cTui.globalControls.SetText("Move   \t[Up/Down]\nSelect   \t[Enter]\nDump command \t[D]\nBranch Select\t[B]\nBack/Quit  \t[Esc]")
-	cTui.heading.AddItem(cTui.globalControls, 0, 1, false)
+	move := "Move\t[Up/Down]"
+	if cTui.config.VimKeys {
+		move = "Move\t[Up/Down, J/K]"
+	}
 
-	cTui.upperNav = tview.NewFlex().SetDirection(tview.FlexColumn)
-	cTui.upperNav.SetBackgroundColor(tcell.ColorDefault)
-	cTui.layout.AddItem(cTui.upperNav, 0, 2, false)
+	cTui.globalControls.SetText(move + "\nSelect\t[Enter]\n" + cTui.keys.help(globalHelp))
+	cTui.heading.AddItem(cTui.globalControls, 0, 1, false)
 
-	cTui.lowerNav = tview.NewFlex().SetDirection(tview.FlexColumn)
-	cTui.lowerNav.SetBackgroundColor(tcell.ColorDefault)
-	cTui.layout.AddItem(cTui.lowerNav, 0, 3, false)
+	cTui.body = tview.NewFlex()
+	cTui.body.SetBackgroundColor(tcell.ColorDefault)
+	cTui.layout.AddItem(cTui.body, 0, 1, false)
 }
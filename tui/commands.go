@@ -0,0 +1,35 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/jedrw/circlog/circleci"
+)
+
+// The circlog commands that print what each pane shows
+
+func (cTui *CirclogTui) pipelinesCommand() string {
+	return fmt.Sprintf("circlog pipelines %s", cTui.config.Project)
+}
+
+func (cTui *CirclogTui) workflowsCommand(pipeline circleci.Pipeline) string {
+	return fmt.Sprintf("circlog workflows %s -l %s", cTui.config.Project, pipeline.Id)
+}
+
+func (cTui *CirclogTui) jobsCommand(workflow circleci.Workflow) string {
+	return fmt.Sprintf("circlog jobs %s -w %s", cTui.config.Project, workflow.Id)
+}
+
+func (cTui *CirclogTui) stepsCommand(job circleci.Job) string {
+	return fmt.Sprintf("circlog steps %s -j %d", cTui.config.Project, job.JobNumber)
+}
+
+func (cTui *CirclogTui) logsCommand(job circleci.Job, action circleci.Action) string {
+	return fmt.Sprintf("circlog logs %s -j %d -s %d -i %d -a \"%s\"",
+		cTui.config.Project,
+		job.JobNumber,
+		action.Step,
+		action.Index,
+		action.AllocationId,
+	)
+}
@@ -0,0 +1,40 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/jedrw/circlog/logscan"
+)
+
+type logErrors struct {
+	rules logscan.ErrorRules
+	// The region marking each error line, usually its own but the first
+	// search match when that starts the line
+	regions []string
+	current int
+}
+
+func errorRegion(n int) string {
+	return fmt.Sprintf("error-%d", n)
+}
+
+// jumpToError moves to the next or previous error line by delta, wrapping
+// around at either end
+func (l *logsPane) jumpToError(delta int) {
+	if len(l.errors.regions) == 0 {
+		return
+	}
+
+	count := len(l.errors.regions)
+	if l.errors.current < 0 {
+		l.errors.current = 0
+		if delta < 0 {
+			l.errors.current = count - 1
+		}
+	} else {
+		l.errors.current = (l.errors.current + delta%count + count) % count
+	}
+
+	l.view.Highlight(l.errors.regions[l.errors.current]).ScrollToHighlight()
+	l.updateTitle()
+}
@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/jedrw/circlog/logscan"
+)
+
+func (cTui *CirclogTui) logFileName() string {
+	return fmt.Sprintf("%s-%d-%d-%d.log",
+		cTui.config.Project,
+		cTui.state.job.JobNumber,
+		cTui.state.action.Step,
+		cTui.state.action.Index,
+	)
+}
+
+func (l *logsPane) logText(strip bool) string {
+	if strip {
+		return logscan.StripANSI(l.raw)
+	}
+
+	return l.raw
+}
+
+// saveLogs asks where to save the logs of the current action, with their ANSI
+// escape sequences removed when strip is set
+func (l *logsPane) saveLogs(cTui *CirclogTui, strip bool) {
+	if l.raw == "" {
+		return
+	}
+
+	name := "Save to"
+	if strip {
+		name = "Save without colours to"
+	}
+
+	logs := l.logText(strip)
+	l.openPrompt(cTui, name, cTui.logFileName(), nil, func(path string) {
+		if path == "" {
+			return
+		}
+
+		err := os.WriteFile(path, []byte(logs), 0644)
+		if err != nil {
+			l.view.SetTitle(fmt.Sprintf(" LOGS - Could not save: %s ", err))
+		} else {
+			l.view.SetTitle(fmt.Sprintf(" LOGS - Saved to %s ", path))
+		}
+	})
+}
+
+// openLogsIn suspends the TUI and opens the logs of the current action in the
+// program named by envVar, or fallback when it is unset
+func (l *logsPane) openLogsIn(cTui *CirclogTui, envVar string, fallback string, strip bool) {
+	if l.raw == "" {
+		return
+	}
+
+	program := os.Getenv(envVar)
+	if program == "" {
+		program = fallback
+	}
+
+	file, err := os.CreateTemp("", "circlog-*.log")
+	if err != nil {
+		l.view.SetTitle(fmt.Sprintf(" LOGS - Could not open %s: %s ", envVar, err))
+		return
+	}
+
+	defer os.Remove(file.Name())
+
+	_, err = file.WriteString(l.logText(strip))
+	file.Close()
+	if err != nil {
+		l.view.SetTitle(fmt.Sprintf(" LOGS - Could not open %s: %s ", envVar, err))
+		return
+	}
+
+	cTui.app.Suspend(func() {
+		// Programs may be given with arguments, such as "less -S"
+		cmd := exec.Command("sh", "-c", program+` "$1"`, "sh", file.Name())
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		cmd.Env = os.Environ()
+		if _, ok := os.LookupEnv("LESS"); !ok {
+			cmd.Env = append(cmd.Env, "LESS=-R")
+		}
+
+		err = cmd.Run()
+	})
+
+	if err != nil {
+		l.view.SetTitle(fmt.Sprintf(" LOGS - %s failed: %s ", program, err))
+	}
+}
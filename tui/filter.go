@@ -0,0 +1,71 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/jedrw/circlog/logscan"
+)
+
+const maxFilterContext = 20
+
+type logFilter struct {
+	text    string
+	regex   bool
+	pattern *regexp.Regexp
+	context int
+}
+
+// toggleFilter turns off an active filter, otherwise it asks for the pattern
+// to filter the logs by
+func (l *logsPane) toggleFilter(cTui *CirclogTui) {
+	if l.filter.pattern != nil {
+		l.filter.pattern = nil
+		l.refilter()
+
+		return
+	}
+
+	l.openPrompt(cTui, "Filter", l.filter.text, &l.filter.regex, func(text string) {
+		l.applyFilter(text)
+	})
+}
+
+func (l *logsPane) applyFilter(text string) {
+	l.filter.text = text
+	if text == "" {
+		return
+	}
+
+	pattern, err := logscan.CompilePattern(text, l.filter.regex)
+	if err != nil {
+		l.view.SetTitle(fmt.Sprintf(" LOGS - Invalid filter: %s ", err))
+
+		return
+	}
+
+	l.filter.pattern = pattern
+	l.refilter()
+}
+
+func (l *logsPane) changeFilterContext(delta int) {
+	if l.filter.pattern == nil {
+		return
+	}
+
+	l.filter.context = min(max(l.filter.context+delta, 0), maxFilterContext)
+	l.refilter()
+}
+
+// refilter re-renders the logs after the filter changes, the lines shown no
+// longer line up with the previous scroll position
+func (l *logsPane) refilter() {
+	l.render()
+	if l.autoScroll {
+		l.view.ScrollToEnd()
+	} else {
+		l.view.ScrollToBeginning()
+	}
+
+	l.updateTitle()
+}
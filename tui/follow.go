@@ -9,7 +9,7 @@ func toggleFollow(cTui *CirclogTui) {
 				cTui.steps.follow = true
 				cTui.logs.autoScroll = true
 				cTui.steps.tree.SetTitle(" STEPS - Follow Enabled ")
-				cTui.logs.view.SetTitle(" LOGS - Autoscroll Enabled ")
+				cTui.logs.updateTitle()
 				steps := cTui.steps.tree.GetRoot().GetChildren()
 				latestStepActions := steps[len(steps)-1].GetChildren()
 				for n := len(latestStepActions) - 1; n >= 0; n-- {
@@ -0,0 +1,214 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jedrw/circlog/theme"
+	"github.com/rivo/tview"
+)
+
+type pane interface {
+	runAction(cTui *CirclogTui, action action) bool
+}
+
+// runAction runs an action of the TUI as a whole, otherwise one of pane
+func (cTui *CirclogTui) runAction(pane pane, action action) bool {
+	switch action {
+
+	case actionHelp:
+		cTui.openHelp()
+
+	case actionPalette:
+		cTui.openPalette()
+
+	case actionSwitchProject:
+		cTui.clearAll()
+		cTui.config.Project = ""
+		cTui.config.Branch = ""
+		cTui.app.SetFocus(cTui.projectSelect)
+
+	case actionZoom:
+		cTui.toggleZoom()
+
+	case actionGrow:
+		cTui.resizePane(1)
+
+	case actionShrink:
+		cTui.resizePane(-1)
+
+	case actionLayout:
+		cTui.cycleLayout()
+
+	default:
+		return pane.runAction(cTui, action)
+	}
+
+	return true
+}
+
+// focusedPane returns the focused pane and the actions available in it
+func (cTui *CirclogTui) focusedPane() (pane, []action) {
+	switch cTui.app.GetFocus() {
+	case cTui.pipelines.table:
+		return &cTui.pipelines, pipelinesActions
+	case cTui.workflows.table:
+		return &cTui.workflows, workflowsActions
+	case cTui.jobs.table:
+		return &cTui.jobs, jobsActions
+	case cTui.steps.tree:
+		return &cTui.steps, stepsActions
+	case cTui.logs.view:
+		return &cTui.logs, logsActions
+	}
+
+	return nil, nil
+}
+
+func (cTui *CirclogTui) openHelp() {
+	_, paneActions := cTui.focusedPane()
+
+	lines := [][2]string{{"Up/Down", "Move"}, {"Enter", "Select"}}
+	for _, action := range paneActions {
+		lines = append(lines, [2]string{cTui.keys.keyNames(action), actions[action].description})
+	}
+
+	keyWidth := 0
+	for _, line := range lines {
+		keyWidth = max(keyWidth, len(line[0]))
+	}
+
+	var text strings.Builder
+	width := 0
+	for _, line := range lines {
+		keys := line[0]
+		if keys == "" {
+			keys = "-"
+		}
+
+		fmt.Fprintf(&text, "%s%-*s[-]  %s\n", colours.Tag(theme.ACCENT, "", ""), keyWidth, tview.Escape(keys), line[1])
+		width = max(width, keyWidth+2+len(line[1]))
+	}
+
+	view := tview.NewTextView().SetDynamicColors(true).SetText(strings.TrimSuffix(text.String(), "\n"))
+	view.SetTitle(" HELP ").SetBorder(true).SetBorderPadding(0, 0, 1, 1)
+	view.SetBackgroundColor(tcell.ColorDefault)
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc || event.Key() == tcell.KeyEnter || event.Rune() == 'q' || event.Rune() == '?' {
+			cTui.hideModal("help")
+
+			return nil
+		}
+
+		return event
+	})
+
+	cTui.showModal("help", view, width+4, len(lines)+2)
+}
+
+// openPalette lets any of the actions of the focused pane be searched for and
+// run
+func (cTui *CirclogTui) openPalette() {
+	pane, paneActions := cTui.focusedPane()
+	if pane == nil {
+		return
+	}
+
+	input := tview.NewInputField().SetLabel(": ")
+	input.SetBackgroundColor(tcell.ColorDefault)
+	input.SetFieldBackgroundColor(tcell.ColorDefault)
+	input.SetLabelStyle(tcell.StyleDefault.Background(tcell.ColorReset).Foreground(tcell.ColorReset))
+
+	list := tview.NewList().ShowSecondaryText(false)
+	list.SetBackgroundColor(tcell.ColorDefault)
+	list.SetMainTextStyle(tcell.StyleDefault.Background(tcell.ColorDefault))
+
+	var matching []action
+	populate := func(query string) {
+		list.Clear()
+		matching = nil
+		for _, action := range paneActions {
+			// Opening the palette from itself makes no sense
+			if action == actionPalette || !fuzzyMatch(query, actions[action].description) {
+				continue
+			}
+
+			label := actions[action].description
+			if keys := cTui.keys.keyNames(action); keys != "" {
+				label = fmt.Sprintf("%s %s(%s)[-]", label, colours.Tag(theme.MUTED, "", ""), tview.Escape(keys))
+			}
+
+			list.AddItem(label, "", 0, nil)
+			matching = append(matching, action)
+		}
+	}
+
+	run := func() {
+		cTui.hideModal("palette")
+		if len(matching) != 0 {
+			cTui.runAction(pane, matching[list.GetCurrentItem()])
+		}
+	}
+
+	populate("")
+	input.SetChangedFunc(populate)
+	input.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+
+		case tcell.KeyUp, tcell.KeyDown, tcell.KeyPgUp, tcell.KeyPgDn:
+			list.InputHandler()(event, nil)
+
+			return nil
+
+		case tcell.KeyEnter:
+			run()
+
+			return nil
+
+		case tcell.KeyEsc:
+			cTui.hideModal("palette")
+
+			return nil
+		}
+
+		return event
+	})
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(input, 1, 0, true).
+		AddItem(list, 0, 1, false)
+	layout.SetTitle(" COMMANDS ").SetBorder(true).SetBorderPadding(0, 0, 1, 1)
+	layout.SetBackgroundColor(tcell.ColorDefault)
+
+	cTui.showModal("palette", layout, 50, min(len(paneActions), 12)+3)
+	cTui.app.SetFocus(input)
+}
+
+// fuzzyMatch reports whether the characters of query appear in text in order,
+// ignoring case
+func fuzzyMatch(query string, text string) bool {
+	remaining := []rune(strings.ToLower(text))
+	for _, ch := range strings.ToLower(query) {
+		if unicode.IsSpace(ch) {
+			continue
+		}
+
+		found := false
+		for len(remaining) > 0 {
+			next := remaining[0]
+			remaining = remaining[1:]
+			if next == ch {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,191 @@
+package tui
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jedrw/circlog/circleci"
+	"github.com/jedrw/circlog/theme"
+	"github.com/rivo/tview"
+)
+
+const maxInfoWidth = 100
+
+// openJobInfo shows everything known about job, fetching its details first.
+// Approval jobs have no details of their own.
+func (cTui *CirclogTui) openJobInfo(job circleci.Job) {
+	go func() {
+		var details *circleci.JobInfo
+		var err error
+		if job.JobNumber != 0 {
+			var info circleci.JobInfo
+			info, err = circleci.GetJobInfo(cTui.config, job.JobNumber)
+			details = &info
+		}
+
+		cTui.app.QueueUpdateDraw(func() {
+			if err != nil {
+				cTui.flash(fmt.Sprintf("Could not get job details: %s", err))
+				details = nil
+			}
+
+			cTui.showInfo(" JOB ", jobInfo(job, details))
+		})
+	}()
+}
+
+func jobInfo(job circleci.Job, details *circleci.JobInfo) [][2]string {
+	lines := [][2]string{
+		{"Name", job.Name},
+		{"Type", job.Type},
+		{"Status", job.Status},
+		{"Started", formatTime(job.StartedAt)},
+		{"Stopped", formatTime(job.StoppedAt)},
+		{"Duration", job.Duration().String()},
+		{"Approved by", job.ApprovedBy},
+		{"Canceled by", job.CanceledBy},
+		{"Project", job.ProjectSlug},
+	}
+
+	if details == nil {
+		return lines
+	}
+
+	var parallelRuns []string
+	for _, run := range details.ParallelRuns {
+		parallelRuns = append(parallelRuns, fmt.Sprintf("%d %s", run.Index, run.Status))
+	}
+
+	var contexts []string
+	for _, context := range details.Contexts {
+		contexts = append(contexts, context.Name)
+	}
+
+	lines = append(lines,
+		[2]string{"Queued", formatTime(details.QueuedAt)},
+		[2]string{"Executor", details.Executor.Type},
+		[2]string{"Resource class", details.Executor.ResourceClass},
+		[2]string{"Parallelism", fmt.Sprint(details.Parallelism)},
+		[2]string{"Parallel runs", strings.Join(parallelRuns, ", ")},
+		[2]string{"Contexts", strings.Join(contexts, ", ")},
+		[2]string{"Organisation", details.Organisation.Name},
+		[2]string{"Workflow", details.LatestWorkflow.Name},
+		[2]string{"URL", details.WebUrl},
+	)
+
+	for _, message := range details.Messages {
+		text := message.Message
+		if message.Reason != "" {
+			text = fmt.Sprintf("%s (%s)", text, message.Reason)
+		}
+
+		lines = append(lines, [2]string{"Message", fmt.Sprintf("%s: %s", message.Type, text)})
+	}
+
+	return lines
+}
+
+func pipelineInfo(pipeline circleci.Pipeline) [][2]string {
+	lines := [][2]string{
+		{"Number", fmt.Sprint(pipeline.Number)},
+		{"State", pipeline.State},
+		{"Branch", pipeline.Vcs.Branch},
+		{"Tag", pipeline.Vcs.Tag},
+		{"Revision", pipeline.Vcs.Revision},
+		{"Subject", pipeline.Vcs.Commit.Subject},
+		{"Body", pipeline.Vcs.Commit.Body},
+		{"Actor", pipeline.Trigger.Actor.Login},
+		{"Trigger", pipeline.Trigger.Type},
+		{"Received", formatTime(pipeline.Trigger.ReceivedAt)},
+		{"Created", formatTime(pipeline.CreatedAt)},
+		{"Review", pipeline.Vcs.ReviewUrl},
+		{"Repository", pipeline.Vcs.TargetRepositoryUrl},
+	}
+
+	for _, parameter := range flattenParameters("", pipeline.TriggerParameters) {
+		lines = append(lines, [2]string{"Parameters", parameter})
+	}
+
+	for _, pipelineError := range pipeline.Errors {
+		lines = append(lines, [2]string{"Errors", fmt.Sprintf("%s: %s", pipelineError.Type, pipelineError.Message)})
+	}
+
+	return lines
+}
+
+// flattenParameters lists nested trigger parameters as "name.name: value"
+func flattenParameters(prefix string, parameters map[string]any) []string {
+	var flattened []string
+	for _, name := range slices.Sorted(maps.Keys(parameters)) {
+		switch value := parameters[name].(type) {
+		case nil:
+
+		case map[string]any:
+			flattened = append(flattened, flattenParameters(prefix+name+".", value)...)
+
+		default:
+			flattened = append(flattened, fmt.Sprintf("%s%s: %v", prefix, name, value))
+		}
+	}
+
+	return flattened
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Local().Format(time.RFC822Z)
+}
+
+// showInfo shows lines of names and values in a modal, skipping those without
+// a value. Values may span several lines and a name repeated on consecutive
+// lines is only shown once.
+func (cTui *CirclogTui) showInfo(title string, lines [][2]string) {
+	nameWidth := 0
+	for _, line := range lines {
+		nameWidth = max(nameWidth, len(line[0]))
+	}
+
+	var text strings.Builder
+	width, height := 0, 0
+	previous := ""
+	for _, line := range lines {
+		if line[1] == "" {
+			continue
+		}
+
+		for i, value := range strings.Split(strings.TrimRight(line[1], "\n"), "\n") {
+			name := line[0]
+			if i > 0 || name == previous {
+				name = ""
+			}
+
+			fmt.Fprintf(&text, "%s%-*s[-]  %s\n", colours.Tag(theme.ACCENT, "", ""), nameWidth, name, tview.Escape(value))
+			width = max(width, nameWidth+2+len(value))
+			height += max(nameWidth+2+len(value)-1, 0)/maxInfoWidth + 1
+		}
+
+		previous = line[0]
+	}
+
+	view := tview.NewTextView().SetDynamicColors(true).SetText(strings.TrimSuffix(text.String(), "\n"))
+	view.SetTitle(title).SetBorder(true).SetBorderPadding(0, 0, 1, 1)
+	view.SetBackgroundColor(tcell.ColorDefault)
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc || event.Key() == tcell.KeyEnter || event.Rune() == 'q' || event.Rune() == 'i' {
+			cTui.hideModal("info")
+
+			return nil
+		}
+
+		return event
+	})
+
+	cTui.showModal("info", view, min(width, maxInfoWidth)+4, height+2)
+}
@@ -8,11 +8,14 @@ import (
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/jedrw/circlog/circleci"
+	"github.com/jedrw/circlog/theme"
 	"github.com/rivo/tview"
 )
 
 type jobsPane struct {
 	table       *tview.Table
+	watchlist   *watchlist
+	pager       *circleci.Pager[circleci.Job]
 	numPages    int
 	watchCtx    context.Context
 	watchCancel context.CancelFunc
@@ -23,7 +26,7 @@ func (cTui *CirclogTui) newJobsPane() jobsPane {
 	table.SetTitle(" JOBS ")
 	table.SetBackgroundColor(tcell.ColorDefault)
 	table.SetBorder(true)
-	table.SetBorderColor(tcell.ColorGrey)
+	table.SetBorderColor(colours.Colour(theme.BORDER))
 	table.SetSelectable(true, false).SetFixed(1, 0).SetSeparator(tview.Borders.Vertical)
 
 	for column, header := range []string{"Name", "Duration", "Depends on"} {
@@ -37,6 +40,8 @@ func (cTui *CirclogTui) newJobsPane() jobsPane {
 
 		case circleci.Job:
 			cTui.state.job = cellRef
+			cTui.steps.collapsed = map[string]bool{}
+			cTui.steps.selected = nil
 			jobDetails, _ := circleci.GetJobSteps(cTui.config, cTui.state.job.JobNumber)
 			cTui.steps.populateStepsTree(cTui.state.job, jobDetails)
 			cTui.app.SetFocus(cTui.steps.tree)
@@ -44,44 +49,28 @@ func (cTui *CirclogTui) newJobsPane() jobsPane {
 		case string:
 			if cell.Text == "..." {
 				cTui.jobs.restartWatcher(cTui, func() {
-					nextPageToken := cell.GetReference().(string)
-					newJobs, nextPageToken, _ := circleci.GetWorkflowJobs(cTui.config, cTui.state.workflow.Id, 1, nextPageToken)
-					cTui.jobs.addJobsToTable(newJobs, table.GetRowCount()-1, nextPageToken)
-					cTui.jobs.numPages++
+					err := cTui.jobs.loadNextPage()
+					if err != nil {
+						cTui.flash(fmt.Sprintf("Could not load jobs: %s", err))
+					}
 				})
 			}
 		}
 	})
 
 	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		if event.Key() == tcell.KeyEsc {
-			cTui.jobs.watchCancel()
-			cTui.jobs.clear()
-			table.SetBorderColor(tcell.ColorGrey)
-			cTui.app.SetFocus(cTui.workflows.table)
-
-			return event
-		}
-
-		switch event.Rune() {
-
-		case 'b':
-			cTui.clearAll()
-			cTui.config.Branch = ""
-			cTui.app.SetFocus(cTui.branchSelect)
-
-		case 'd':
-			cTui.app.Stop()
-			fmt.Printf("circlog jobs %s -w %s\n", cTui.config.Project, cTui.state.workflow.Id)
+		if cTui.runAction(&cTui.jobs, cTui.keys.match(event, jobsActions)) {
+			return nil
 		}
 
 		return event
 	})
 
 	table.SetFocusFunc(func() {
+		cTui.saveSession()
 		cTui.jobs.restartWatcher(cTui, func() {
-			table.SetBorderColor(tcell.ColorDefault)
-			cTui.paneControls.Clear()
+			table.SetBorderColor(colours.Colour(theme.FOCUSED_BORDER))
+			cTui.paneControls.SetText(cTui.keys.help(jobsHelp))
 		})
 	})
 
@@ -89,6 +78,7 @@ func (cTui *CirclogTui) newJobsPane() jobsPane {
 
 	return jobsPane{
 		table:       table,
+		watchlist:   cTui.watchlist,
 		numPages:    1,
 		watchCtx:    watchCtx,
 		watchCancel: watchCancel,
@@ -103,7 +93,7 @@ func (j *jobsPane) watchJobs(ctx context.Context, cTui *CirclogTui) {
 LOOP:
 	for {
 		go func() {
-			jobs, nextPageToken, _ := circleci.GetWorkflowJobs(cTui.config, cTui.state.workflow.Id, cTui.workflows.numPages, "")
+			jobs, nextPageToken, _ := circleci.GetWorkflowJobs(cTui.config, cTui.state.workflow.Id, j.numPages, "")
 			jobsChan <- jobs
 			nextPageTokenChan <- nextPageToken
 		}()
@@ -132,6 +122,80 @@ func (j *jobsPane) restartWatcher(cTui *CirclogTui, fn func()) {
 	go j.watchJobs(j.watchCtx, cTui)
 }
 
+func (j *jobsPane) runAction(cTui *CirclogTui, action action) bool {
+	switch action {
+
+	case actionBack:
+		j.watchCancel()
+		j.clear()
+		j.table.SetBorderColor(colours.Colour(theme.BORDER))
+		cTui.app.SetFocus(cTui.workflows.table)
+
+	case actionWatch:
+		cell := j.table.GetCell(j.table.GetSelection())
+		cellRef, ok := cell.GetReference().(circleci.Job)
+		if ok {
+			j.restartWatcher(cTui, func() {
+				cTui.toggleWatch(watchedJob(cTui.config.Project, cellRef, cTui.state.workflow))
+			})
+		}
+
+	case actionShowInfo:
+		cell := j.table.GetCell(j.table.GetSelection())
+		cellRef, ok := cell.GetReference().(circleci.Job)
+		if ok {
+			cTui.openJobInfo(cellRef)
+		}
+
+	case actionBranchSelect:
+		cTui.clearAll()
+		cTui.config.Branch = ""
+		cTui.app.SetFocus(cTui.branchSelect)
+
+	case actionYank:
+		cell := j.table.GetCell(j.table.GetSelection())
+		cellRef, ok := cell.GetReference().(circleci.Job)
+		if ok {
+			cTui.openYankMenu(cTui.jobYankItems(cTui.jobsCommand(cTui.state.workflow), cellRef))
+		}
+
+	case actionBrowse:
+		cell := j.table.GetCell(j.table.GetSelection())
+		cellRef, ok := cell.GetReference().(circleci.Job)
+		if ok {
+			cTui.openInBrowser(cTui.jobWebUrl(cellRef))
+		}
+
+	case actionDump:
+		cTui.app.Stop()
+		fmt.Println(cTui.jobsCommand(cTui.state.workflow))
+
+	default:
+		return false
+	}
+
+	return true
+}
+
+func (j *jobsPane) loadFirstPage(cTui *CirclogTui) {
+	j.pager = circleci.WorkflowJobsPager(cTui.config, cTui.state.workflow.Id, circleci.JobFilter{}, "")
+	j.numPages = 1
+	jobs, _ := j.pager.NextPage()
+	j.populateTable(jobs, j.pager.NextPageToken())
+}
+
+func (j *jobsPane) loadNextPage() error {
+	jobs, err := j.pager.NextPage()
+	if err != nil {
+		return err
+	}
+
+	j.addJobsToTable(jobs, j.table.GetRowCount()-1, j.pager.NextPageToken())
+	j.numPages++
+
+	return nil
+}
+
 func (j *jobsPane) populateTable(jobs []circleci.Job, nextPageToken string) {
 	j.clear()
 	j.addJobsToTable(jobs, j.table.GetRowCount(), nextPageToken)
@@ -141,7 +205,7 @@ func (j *jobsPane) populateTable(jobs []circleci.Job, nextPageToken string) {
 func (j *jobsPane) addJobsToTable(jobs []circleci.Job, startRow int, nextPageToken string) {
 	if len(jobs) != 0 {
 		for row, job := range jobs {
-			dependencies := getNamedJobDependencies(job, jobs)
+			dependencies := circleci.NamedJobDependencies(job, jobs)
 			var dependenciesString string
 			if len(dependencies) == 0 {
 				dependenciesString = "[]"
@@ -151,14 +215,12 @@ func (j *jobsPane) addJobsToTable(jobs []circleci.Job, startRow int, nextPageTok
 				dependenciesString = fmt.Sprintf("[%s[]", strings.Join(dependencies, ", "))
 			}
 
-			var jobDuration string
-			if job.Status == circleci.RUNNING {
-				jobDuration = time.Since(job.StartedAt).Round(time.Millisecond).String()
-			} else {
-				jobDuration = job.StoppedAt.Sub(job.StartedAt).Round(time.Millisecond).String()
+			name := job.Name
+			if j.watchlist.isWatched(job.Id) {
+				name = watchedMarker + name
 			}
 
-			for column, attr := range []string{job.Name, jobDuration, dependenciesString} {
+			for column, attr := range []string{name, job.Duration().String(), dependenciesString} {
 				cell := tview.NewTableCell(attr).SetStyle(styleForStatus(job.Status))
 				cell.SetReference(job)
 				j.table.SetCell(row+startRow, column, cell)
@@ -166,30 +228,16 @@ func (j *jobsPane) addJobsToTable(jobs []circleci.Job, startRow int, nextPageTok
 		}
 
 		if nextPageToken != "" {
-			cell := tview.NewTableCell("...").SetStyle(tcell.StyleDefault)
-			cell.SetReference(nextPageToken)
-			j.table.SetCell(j.table.GetRowCount(), 0, cell)
+			row := j.table.GetRowCount()
+			j.table.SetCell(row, 0, loadMoreCell(j.table, row, nextPageToken).SetStyle(tcell.StyleDefault))
 		}
 
 	} else {
-		cell := tview.NewTableCell("None").SetStyle(tcell.StyleDefault.Background(tcell.ColorDefault).Foreground(tcell.ColorDarkGray))
+		cell := tview.NewTableCell("None").SetStyle(colours.Style(theme.MUTED))
 		j.table.SetCell(1, 0, cell)
 	}
 }
 
-func getNamedJobDependencies(job circleci.Job, jobs []circleci.Job) []string {
-	var namedDependencies []string
-	for _, dependsOnJobId := range job.Dependencies {
-		for _, requiredJob := range jobs {
-			if requiredJob.Id == dependsOnJobId {
-				namedDependencies = append(namedDependencies, requiredJob.Name)
-			}
-		}
-	}
-
-	return namedDependencies
-}
-
 func (j *jobsPane) clear() {
 	row := 1
 	for row < j.table.GetRowCount() {
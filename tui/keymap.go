@@ -0,0 +1,263 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jedrw/circlog/config"
+	"github.com/rivo/tview"
+)
+
+type action string
+
+const (
+	actionQuit             action = "quit"
+	actionBack             action = "back"
+	actionHelp             action = "help"
+	actionPalette          action = "palette"
+	actionSwitchProject    action = "switch_project"
+	actionBranchSelect     action = "branch_select"
+	actionDump             action = "dump"
+	actionYank             action = "yank"
+	actionBrowse           action = "browse"
+	actionZoom             action = "zoom"
+	actionGrow             action = "grow"
+	actionShrink           action = "shrink"
+	actionLayout           action = "layout"
+	actionFilterBranch     action = "filter_branch"
+	actionWatch            action = "watch"
+	actionShowInfo         action = "info"
+	actionShowConfig       action = "config"
+	actionToggleFollow     action = "toggle_follow"
+	actionToggleAutoScroll action = "toggle_autoscroll"
+	actionSearch           action = "search"
+	actionNextMatch        action = "next_match"
+	actionPreviousMatch    action = "previous_match"
+	actionFilter           action = "filter"
+	actionMoreContext      action = "more_context"
+	actionLessContext      action = "less_context"
+	actionNextError        action = "next_error"
+	actionPreviousError    action = "previous_error"
+	actionSave             action = "save"
+	actionSavePlain        action = "save_plain"
+	actionOpenPager        action = "open_pager"
+	actionOpenEditor       action = "open_editor"
+)
+
+type actionInfo struct {
+	description string
+	keys        []string
+}
+
+var actions = map[action]actionInfo{
+	actionQuit:             {"Quit", []string{"esc"}},
+	actionBack:             {"Back", []string{"esc"}},
+	actionHelp:             {"Help", []string{"?"}},
+	actionPalette:          {"Commands", []string{":"}},
+	actionSwitchProject:    {"Switch project", nil},
+	actionBranchSelect:     {"Branch select", []string{"b"}},
+	actionDump:             {"Dump", []string{"d"}},
+	actionYank:             {"Yank", []string{"y"}},
+	actionBrowse:           {"Browse", []string{"O"}},
+	actionZoom:             {"Zoom", []string{"z"}},
+	actionGrow:             {"Grow pane", []string{"+"}},
+	actionShrink:           {"Shrink pane", []string{"-"}},
+	actionLayout:           {"Layout", []string{"L"}},
+	actionFilterBranch:     {"Filter by branch", []string{"v"}},
+	actionWatch:            {"Watch", []string{"w"}},
+	actionShowInfo:         {"Info", []string{"i"}},
+	actionShowConfig:       {"Config", []string{"c"}},
+	actionToggleFollow:     {"Toggle follow", []string{"f"}},
+	actionToggleAutoScroll: {"Toggle autoscroll", []string{"a"}},
+	actionSearch:           {"Search", []string{"/"}},
+	actionNextMatch:        {"Next match", []string{"n"}},
+	actionPreviousMatch:    {"Previous match", []string{"N"}},
+	actionFilter:           {"Filter", []string{"&"}},
+	actionMoreContext:      {"More context", []string{"c"}},
+	actionLessContext:      {"Less context", []string{"C"}},
+	actionNextError:        {"Next error", []string{"e"}},
+	actionPreviousError:    {"Previous error", []string{"E"}},
+	actionSave:             {"Save", []string{"s"}},
+	actionSavePlain:        {"Save plain", []string{"S"}},
+	actionOpenPager:        {"Pager", []string{"o"}},
+	actionOpenEditor:       {"Editor", []string{"v"}}, // e is next_error
+}
+
+// The actions handled by each pane, in order of precedence, and how they are
+// grouped into lines of help
+var (
+	inputActions      = []action{actionQuit}
+	navigationActions = []action{actionBack, actionHelp, actionPalette, actionSwitchProject, actionBranchSelect, actionDump, actionYank, actionBrowse, actionZoom, actionGrow, actionShrink, actionLayout}
+	pipelinesActions  = slices.Concat(navigationActions, []action{actionFilterBranch, actionWatch, actionShowInfo, actionShowConfig})
+	workflowsActions  = slices.Concat(navigationActions, []action{actionWatch})
+	jobsActions       = slices.Concat(navigationActions, []action{actionWatch, actionShowInfo})
+	stepsActions      = slices.Concat(navigationActions, []action{actionToggleFollow})
+	logsActions       = slices.Concat(navigationActions, []action{
+		actionToggleAutoScroll, actionToggleFollow,
+		actionSearch, actionNextMatch, actionPreviousMatch,
+		actionFilter, actionMoreContext, actionLessContext,
+		actionNextError, actionPreviousError,
+		actionSave, actionSavePlain, actionOpenPager, actionOpenEditor,
+	})
+
+	globalHelp    = [][]action{{actionDump, actionYank, actionBrowse}, {actionHelp, actionPalette, actionZoom, actionLayout}, {actionBranchSelect, actionBack, actionQuit}}
+	pipelinesHelp = [][]action{{actionFilterBranch}, {actionWatch}, {actionShowInfo, actionShowConfig}}
+	workflowsHelp = [][]action{{actionWatch}}
+	jobsHelp      = [][]action{{actionWatch}, {actionShowInfo}}
+	stepsHelp     = [][]action{{actionToggleFollow}}
+	logsHelp      = [][]action{
+		{actionToggleAutoScroll, actionToggleFollow},
+		{actionSearch, actionNextMatch, actionPreviousMatch},
+		{actionFilter, actionMoreContext, actionLessContext},
+		{actionNextError, actionPreviousError},
+		{actionSave, actionSavePlain, actionOpenPager, actionOpenEditor},
+	}
+)
+
+// vimKeys are translated into the keys tview navigates with when vim_keys is
+// set
+var vimKeys = map[key]tcell.Key{
+	{tcell.KeyRune, 'j'}: tcell.KeyDown,
+	{tcell.KeyRune, 'k'}: tcell.KeyUp,
+	{tcell.KeyRune, 'g'}: tcell.KeyHome,
+	{tcell.KeyRune, 'G'}: tcell.KeyEnd,
+	{tcell.KeyCtrlD, 0}:  tcell.KeyPgDn,
+	{tcell.KeyCtrlU, 0}:  tcell.KeyPgUp,
+}
+
+type key struct {
+	key tcell.Key
+	ch  rune
+}
+
+func eventKey(event *tcell.EventKey) key {
+	if event.Key() == tcell.KeyRune {
+		return key{tcell.KeyRune, event.Rune()}
+	}
+
+	return key{event.Key(), 0}
+}
+
+// parseKey parses a single character or a key name such as "esc", "pgdn" or
+// "ctrl+d"
+func parseKey(name string) (key, error) {
+	if utf8.RuneCountInString(name) == 1 {
+		ch, _ := utf8.DecodeRuneInString(name)
+		return key{tcell.KeyRune, ch}, nil
+	}
+
+	normalised := strings.ReplaceAll(strings.ToLower(name), "+", "-")
+	if normalised == "space" {
+		return key{tcell.KeyRune, ' '}, nil
+	}
+
+	for k, keyName := range tcell.KeyNames {
+		if strings.ToLower(keyName) == normalised {
+			return key{k, 0}, nil
+		}
+	}
+
+	return key{}, fmt.Errorf("unknown key %q", name)
+}
+
+func (k key) String() string {
+	switch {
+	case k.key != tcell.KeyRune:
+		return strings.ReplaceAll(tcell.KeyNames[k.key], "-", "+")
+	case k.ch == ' ':
+		return "Space"
+	case unicode.IsUpper(k.ch):
+		return "Shift+" + string(k.ch)
+	default:
+		return string(unicode.ToUpper(k.ch))
+	}
+}
+
+type keymap map[action][]key
+
+// newKeymap returns the default keymap with the keys of any actions in
+// overrides replaced
+func newKeymap(overrides map[string]config.KeyList) (keymap, error) {
+	keys := keymap{}
+	for name, info := range actions {
+		names := info.keys
+		if override, ok := overrides[string(name)]; ok {
+			names = override
+		}
+
+		for _, keyName := range names {
+			k, err := parseKey(keyName)
+			if err != nil {
+				return nil, fmt.Errorf("invalid key for %s: %w", name, err)
+			}
+
+			keys[name] = append(keys[name], k)
+		}
+	}
+
+	for name := range overrides {
+		if _, ok := actions[action(name)]; !ok {
+			return nil, fmt.Errorf("unknown action %q in keys", name)
+		}
+	}
+
+	return keys, nil
+}
+
+// match returns the first of actions bound to the key of event, or "" when
+// none are
+func (keys keymap) match(event *tcell.EventKey, actions []action) action {
+	pressed := eventKey(event)
+	for _, action := range actions {
+		if slices.Contains(keys[action], pressed) {
+			return action
+		}
+	}
+
+	return ""
+}
+
+func (keys keymap) keyNames(action action) string {
+	var names []string
+	for _, k := range keys[action] {
+		names = append(names, k.String())
+	}
+
+	return strings.Join(names, "/")
+}
+
+// help describes each group of actions on a line of its own
+func (keys keymap) help(groups [][]action) string {
+	var lines []string
+	for _, group := range groups {
+		var descriptions, keyNames []string
+		for _, action := range group {
+			descriptions = append(descriptions, actions[action].description)
+			names := keys.keyNames(action)
+			if names != "" && !slices.Contains(keyNames, names) {
+				keyNames = append(keyNames, names)
+			}
+		}
+
+		lines = append(lines, fmt.Sprintf("%s\t[%s]", strings.Join(descriptions, ", "), strings.Join(keyNames, ", ")))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// translateVimKeys lets vim keys navigate everywhere but text inputs
+func (cTui *CirclogTui) translateVimKeys(event *tcell.EventKey) *tcell.EventKey {
+	if _, ok := cTui.app.GetFocus().(*tview.InputField); ok {
+		return event
+	}
+
+	if translated, ok := vimKeys[eventKey(event)]; ok {
+		return tcell.NewEventKey(translated, 0, tcell.ModNone)
+	}
+
+	return event
+}
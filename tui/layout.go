@@ -0,0 +1,270 @@
+package tui
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jedrw/circlog/config"
+	"github.com/rivo/tview"
+)
+
+const (
+	layoutDefault = "default"
+	layoutColumns = "columns"
+	layoutStacked = "stacked"
+	layoutLogs    = "logs"
+)
+
+const maxPaneSize = 10
+
+// layoutFile keeps the layout as last changed in the TUI, apart from the
+// config file so that it is never rewritten
+const layoutFile = "layout.json"
+
+// layoutNames is the order layouts are cycled through in
+var layoutNames = []string{layoutDefault, layoutColumns, layoutStacked, layoutLogs}
+
+type paneGroup struct {
+	name  string
+	panes []string
+}
+
+type arrangement struct {
+	direction      int
+	groupDirection int
+	groups         []paneGroup
+}
+
+var defaultArrangement = arrangement{tview.FlexRow, tview.FlexColumn, []paneGroup{
+	{"upper", []string{"pipelines", "workflows", "jobs"}},
+	{"lower", []string{"steps", "logs"}},
+}}
+
+// layouts arrange the panes in groups, the logs layout being the default
+// arrangement with the logs zoomed whenever they are focused
+var layouts = map[string]arrangement{
+	layoutDefault: defaultArrangement,
+	layoutColumns: {tview.FlexColumn, tview.FlexRow, []paneGroup{
+		{"left", []string{"pipelines", "workflows", "jobs", "steps"}},
+		{"right", []string{"logs"}},
+	}},
+	layoutStacked: {tview.FlexRow, tview.FlexRow, []paneGroup{
+		{"stack", []string{"pipelines", "workflows", "jobs", "steps", "logs"}},
+	}},
+	layoutLogs: defaultArrangement,
+}
+
+// defaultPaneSizes are the proportions of each pane within its group and of
+// each group within the TUI
+var defaultPaneSizes = map[string]int{
+	"pipelines": 1,
+	"workflows": 1,
+	"jobs":      1,
+	"steps":     1,
+	"logs":      2,
+	"upper":     2,
+	"lower":     3,
+	"left":      1,
+	"right":     2,
+	"stack":     1,
+}
+
+type savedLayout struct {
+	Layout    string         `json:"layout"`
+	PaneSizes map[string]int `json:"pane_sizes"`
+}
+
+type paneLayout struct {
+	name     string
+	sizes    map[string]int
+	zoomed   bool
+	arranged string
+}
+
+func newPaneLayout(name string, sizes map[string]int) (paneLayout, error) {
+	if name == "" {
+		name = layoutDefault
+	}
+
+	if _, ok := layouts[name]; !ok {
+		return paneLayout{}, fmt.Errorf("unknown layout %q, valid layouts are %v", name, layoutNames)
+	}
+
+	merged := maps.Clone(defaultPaneSizes)
+	for pane, size := range sizes {
+		if _, ok := defaultPaneSizes[pane]; !ok {
+			return paneLayout{}, fmt.Errorf("unknown pane %q in pane_sizes", pane)
+		}
+
+		if size < 1 || size > maxPaneSize {
+			return paneLayout{}, fmt.Errorf("size of %s must be between 1 and %d", pane, maxPaneSize)
+		}
+
+		merged[pane] = size
+	}
+
+	return paneLayout{name: name, sizes: merged}, nil
+}
+
+// loadPaneLayout returns the configured layout with any changes saved by the
+// TUI applied on top
+func loadPaneLayout(name string, sizes map[string]int) (paneLayout, error) {
+	path, err := config.FilePath(layoutFile)
+	if err != nil {
+		return paneLayout{}, err
+	}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return newPaneLayout(name, sizes)
+	} else if err != nil {
+		return paneLayout{}, err
+	}
+
+	var saved savedLayout
+	err = json.Unmarshal(b, &saved)
+	if err != nil {
+		return paneLayout{}, fmt.Errorf("could not parse %s", path)
+	}
+
+	merged := maps.Clone(sizes)
+	if merged == nil {
+		merged = map[string]int{}
+	}
+
+	maps.Copy(merged, saved.PaneSizes)
+
+	return newPaneLayout(cmp.Or(saved.Layout, name), merged)
+}
+
+func (cTui *CirclogTui) paneNamed(name string) tview.Primitive {
+	switch name {
+	case "pipelines":
+		return cTui.pipelines.table
+	case "workflows":
+		return cTui.workflows.table
+	case "jobs":
+		return cTui.jobs.table
+	case "steps":
+		return cTui.steps.tree
+	}
+
+	return cTui.logs.layout
+}
+
+// focusedPaneName returns the name of the focused pane, or the pane focused
+// before a modal was shown, and "" when no pane is focused
+func (cTui *CirclogTui) focusedPaneName() string {
+	focus := cTui.app.GetFocus()
+	if cTui.modalContent != nil {
+		focus = cTui.modalReturn
+	}
+
+	switch focus {
+	case cTui.pipelines.table:
+		return "pipelines"
+	case cTui.workflows.table:
+		return "workflows"
+	case cTui.jobs.table:
+		return "jobs"
+	case cTui.steps.tree:
+		return "steps"
+	case cTui.logs.view, cTui.logs.prompt.input:
+		return "logs"
+	}
+
+	return ""
+}
+
+// arrangePanes lays the panes out in the body of the TUI, filling it with the
+// focused pane when zoomed. It is called before every draw so zooming follows
+// the focus.
+func (cTui *CirclogTui) arrangePanes() {
+	layout := &cTui.paneLayout
+	focused := cTui.focusedPaneName()
+
+	zoomed := ""
+	if layout.zoomed || (layout.name == layoutLogs && focused == "logs") {
+		zoomed = focused
+	}
+
+	arranged := fmt.Sprint(layout.name, zoomed, layout.sizes)
+	if arranged == layout.arranged {
+		return
+	}
+
+	layout.arranged = arranged
+
+	// Hidden panes keep their last position, which would still catch clicks
+	cTui.body.Clear()
+	for _, pane := range cTui.drillOrder() {
+		pane.SetRect(0, 0, 0, 0)
+	}
+
+	if zoomed != "" {
+		cTui.body.AddItem(cTui.paneNamed(zoomed), 0, 1, false)
+		return
+	}
+
+	arrangement := layouts[layout.name]
+	cTui.body.SetDirection(arrangement.direction)
+	for _, group := range arrangement.groups {
+		flex := tview.NewFlex().SetDirection(arrangement.groupDirection)
+		flex.SetBackgroundColor(tcell.ColorDefault)
+		for _, pane := range group.panes {
+			flex.AddItem(cTui.paneNamed(pane), 0, layout.sizes[pane], false)
+		}
+
+		cTui.body.AddItem(flex, 0, layout.sizes[group.name], false)
+	}
+}
+
+func (cTui *CirclogTui) toggleZoom() {
+	cTui.paneLayout.zoomed = !cTui.paneLayout.zoomed
+}
+
+// resizePane grows or shrinks the focused pane along with its group
+func (cTui *CirclogTui) resizePane(delta int) {
+	layout := &cTui.paneLayout
+	focused := cTui.focusedPaneName()
+	for _, group := range layouts[layout.name].groups {
+		if slices.Contains(group.panes, focused) {
+			for _, name := range []string{focused, group.name} {
+				layout.sizes[name] = min(max(layout.sizes[name]+delta, 1), maxPaneSize)
+			}
+		}
+	}
+
+	cTui.saveLayout()
+}
+
+func (cTui *CirclogTui) cycleLayout() {
+	layout := &cTui.paneLayout
+	next := (slices.Index(layoutNames, layout.name) + 1) % len(layoutNames)
+	layout.name = layoutNames[next]
+	layout.zoomed = false
+
+	cTui.flash(fmt.Sprintf("Layout %s", layout.name))
+	cTui.saveLayout()
+}
+
+func (cTui *CirclogTui) saveLayout() {
+	path, err := config.FilePath(layoutFile)
+	if err == nil {
+		var b []byte
+		b, err = json.MarshalIndent(savedLayout{cTui.paneLayout.name, cTui.paneLayout.sizes}, "", "  ")
+		if err == nil {
+			err = os.WriteFile(path, b, 0644)
+		}
+	}
+
+	if err != nil {
+		cTui.flash(fmt.Sprintf("Could not save layout: %s", err))
+	}
+}
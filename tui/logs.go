@@ -3,109 +3,73 @@ package tui
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/jedrw/circlog/circleci"
+	"github.com/jedrw/circlog/logscan"
+	"github.com/jedrw/circlog/theme"
 	"github.com/rivo/tview"
 )
 
 type logsPane struct {
+	layout      *tview.Flex
 	view        *tview.TextView
 	autoScroll  bool
+	raw         string
+	search      logSearch
+	filter      logFilter
+	errors      logErrors
+	prompt      logsPrompt
 	watchCtx    context.Context
 	watchCancel context.CancelFunc
 }
 
-func (cTui *CirclogTui) newLogsPane() logsPane {
+func (cTui *CirclogTui) newLogsPane(errorRules logscan.ErrorRules) logsPane {
 	view := tview.NewTextView()
 	view.SetTitle(" LOGS - Autoscroll Enabled ")
 	view.SetBackgroundColor(tcell.ColorDefault)
 	view.SetBorder(true).SetBorderPadding(0, 0, 1, 1)
-	view.SetBorderColor(tcell.ColorGrey)
+	view.SetBorderColor(colours.Colour(theme.BORDER))
 	view.SetDynamicColors(true)
+	view.SetRegions(true)
 	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		switch event.Key() {
-
-		case tcell.KeyEsc:
-			cTui.logs.watchCancel()
-			cTui.steps.restartWatcher(cTui, func() {
-				if cTui.steps.follow {
-					cTui.steps.follow = false
-					cTui.steps.tree.SetTitle(" STEPS - Follow Disabled ")
-				}
-
-				view.Clear()
-				view.SetBorderColor(tcell.ColorGrey)
-
-				cTui.app.SetFocus(cTui.steps.tree)
-			})
-
-			return event
-
-		case tcell.KeyUp:
-			cTui.logs.restartWatcher(cTui, func() {
-				cTui.logs.autoScroll = false
-				view.SetTitle(" LOGS - Autoscroll Disabled ")
-				cTui.steps.restartWatcher(cTui, func() {
-					cTui.steps.follow = false
-					cTui.steps.tree.SetTitle(" STEPS - Follow Disabled ")
-				})
-			})
+		if event.Key() == tcell.KeyUp {
+			cTui.logs.disableAutoScroll(cTui)
 
 			return event
 		}
 
-		switch event.Rune() {
-
-		case 'f':
-			toggleFollow(cTui)
-
-		case 'a':
-			cTui.logs.restartWatcher(cTui, func() {
-				cTui.logs.autoScroll = !cTui.logs.autoScroll
-				cTui.steps.restartWatcher(cTui, func() {
-					if cTui.logs.autoScroll {
-						view.SetTitle(" LOGS - Autoscroll Enabled ")
-						view.ScrollToEnd()
-					} else {
-						cTui.steps.follow = false
-						cTui.steps.tree.SetTitle(" STEPS - Follow Disabled ")
-						view.SetTitle(" LOGS - Autoscroll Disabled ")
-					}
-				})
-			})
-
-		case 'b':
-			cTui.clearAll()
-			cTui.config.Branch = ""
-			cTui.app.SetFocus(cTui.branchSelect)
-
-		case 'd':
-			cTui.app.Stop()
-			fmt.Printf("circlog logs %s -j %d -s %d -i %d -a \"%s\"\n",
-				cTui.config.Project,
-				cTui.state.job.JobNumber,
-				cTui.state.action.Step,
-				cTui.state.action.Index,
-				cTui.state.action.AllocationId,
-			)
+		if cTui.runAction(&cTui.logs, cTui.keys.match(event, logsActions)) {
+			return nil
 		}
 
 		return event
 	})
 
 	view.SetFocusFunc(func() {
+		cTui.saveSession()
 		cTui.logs.restartWatcher(cTui, func() {
-			view.SetBorderColor(tcell.ColorDefault)
-			cTui.paneControls.SetText("Toggle Autoscroll\t[A]")
+			view.SetBorderColor(colours.Colour(theme.FOCUSED_BORDER))
+			cTui.paneControls.SetText(cTui.keys.help(logsHelp))
 		})
 	})
 
+	promptInput := cTui.newPromptInput()
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow)
+	layout.SetBackgroundColor(tcell.ColorDefault)
+	layout.AddItem(view, 0, 1, false)
+	layout.AddItem(promptInput, 0, 0, false)
+
 	watchCtx, watchCancel := context.WithCancel(context.Background())
 
 	return logsPane{
+		layout:      layout,
 		view:        view,
+		prompt:      logsPrompt{input: promptInput},
+		errors:      logErrors{rules: errorRules, current: -1},
 		autoScroll:  true,
 		watchCtx:    watchCtx,
 		watchCancel: watchCancel,
@@ -159,6 +123,257 @@ func (l *logsPane) restartWatcher(cTui *CirclogTui, fn func()) {
 	go l.watchLogs(l.watchCtx, cTui)
 }
 
+func (l *logsPane) runAction(cTui *CirclogTui, action action) bool {
+	switch action {
+
+	case actionBack:
+		l.watchCancel()
+		cTui.steps.restartWatcher(cTui, func() {
+			if cTui.steps.follow {
+				cTui.steps.follow = false
+				cTui.steps.tree.SetTitle(" STEPS - Follow Disabled ")
+			}
+
+			l.clear()
+			l.view.SetBorderColor(colours.Colour(theme.BORDER))
+
+			cTui.app.SetFocus(cTui.steps.tree)
+		})
+
+	case actionSearch:
+		l.openSearch(cTui)
+
+	case actionNextMatch:
+		l.disableAutoScroll(cTui)
+		l.jumpToMatch(1)
+
+	case actionPreviousMatch:
+		l.disableAutoScroll(cTui)
+		l.jumpToMatch(-1)
+
+	case actionFilter:
+		l.toggleFilter(cTui)
+
+	case actionMoreContext:
+		l.changeFilterContext(1)
+
+	case actionLessContext:
+		l.changeFilterContext(-1)
+
+	case actionNextError:
+		l.disableAutoScroll(cTui)
+		l.jumpToError(1)
+
+	case actionPreviousError:
+		l.disableAutoScroll(cTui)
+		l.jumpToError(-1)
+
+	case actionSave:
+		l.saveLogs(cTui, false)
+
+	case actionSavePlain:
+		l.saveLogs(cTui, true)
+
+	case actionOpenPager:
+		l.openLogsIn(cTui, "PAGER", "less", false)
+
+	case actionOpenEditor:
+		l.openLogsIn(cTui, "EDITOR", "vi", true)
+
+	case actionToggleFollow:
+		toggleFollow(cTui)
+
+	case actionToggleAutoScroll:
+		l.restartWatcher(cTui, func() {
+			l.autoScroll = !l.autoScroll
+			cTui.steps.restartWatcher(cTui, func() {
+				if l.autoScroll {
+					l.view.ScrollToEnd()
+				} else {
+					cTui.steps.follow = false
+					cTui.steps.tree.SetTitle(" STEPS - Follow Disabled ")
+				}
+
+				l.updateTitle()
+			})
+		})
+
+	case actionBranchSelect:
+		cTui.clearAll()
+		cTui.config.Branch = ""
+		cTui.app.SetFocus(cTui.branchSelect)
+
+	case actionYank:
+		items := cTui.jobYankItems(cTui.logsCommand(cTui.state.job, cTui.state.action), cTui.state.job)
+		cTui.openYankMenu(append(items, yankItem{"Allocation ID", cTui.state.action.AllocationId}))
+
+	case actionBrowse:
+		cTui.openInBrowser(cTui.jobWebUrl(cTui.state.job))
+
+	case actionDump:
+		cTui.app.Stop()
+		fmt.Println(cTui.logsCommand(cTui.state.job, cTui.state.action))
+
+	default:
+		return false
+	}
+
+	return true
+}
+
+func (l *logsPane) disableAutoScroll(cTui *CirclogTui) {
+	cTui.logs.restartWatcher(cTui, func() {
+		cTui.logs.autoScroll = false
+		cTui.logs.updateTitle()
+		cTui.steps.restartWatcher(cTui, func() {
+			cTui.steps.follow = false
+			cTui.steps.tree.SetTitle(" STEPS - Follow Disabled ")
+		})
+	})
+}
+
+func (l *logsPane) updateTitle() {
+	title := " LOGS - Autoscroll Disabled "
+	if l.autoScroll {
+		title = " LOGS - Autoscroll Enabled "
+	}
+
+	if l.filter.pattern != nil {
+		title = fmt.Sprintf("%s- Filter %q (%d context) ", title, l.filter.text, l.filter.context)
+	}
+
+	if l.errors.current >= 0 {
+		title = fmt.Sprintf("%s- Error %d/%d ", title, l.errors.current+1, len(l.errors.regions))
+	}
+
+	if l.search.pattern != nil {
+		if l.search.matches == 0 {
+			title = fmt.Sprintf("%s- No matches ", title)
+		} else {
+			title = fmt.Sprintf("%s- Match %d/%d ", title, l.search.current+1, l.search.matches)
+		}
+	}
+
+	l.view.SetTitle(title)
+}
+
 func (l *logsPane) updateLogsView(logs string) {
-	l.view.SetText(tview.TranslateANSI(logs))
+	l.raw = logs
+	l.render()
+}
+
+func (l *logsPane) clear() {
+	l.raw = ""
+	l.errors.regions = nil
+	l.errors.current = -1
+	l.view.Clear()
+}
+
+func (l *logsPane) render() {
+	var text strings.Builder
+	l.search.matches = 0
+	l.errors.regions = nil
+	lines := strings.SplitAfter(l.raw, "\n")
+	if l.filter.pattern == nil {
+		for _, line := range lines {
+			text.WriteString(l.renderLine(line))
+		}
+	} else {
+		if lines[len(lines)-1] == "" {
+			lines = lines[:len(lines)-1]
+		}
+
+		for i, group := range logscan.Grep(lines, l.filter.pattern, l.filter.context) {
+			if i != 0 && l.filter.context != 0 {
+				text.WriteString(colours.Tag(theme.MUTED, "", "") + "--[-:-:-]\n")
+			}
+
+			for _, n := range group {
+				text.WriteString(l.renderLine(strings.TrimSuffix(lines[n], "\n") + "\n"))
+			}
+		}
+	}
+
+	l.view.SetText(text.String())
+	switch {
+	case l.errors.current >= 0 && len(l.errors.regions) != 0:
+		l.errors.current = min(l.errors.current, len(l.errors.regions)-1)
+		l.view.Highlight(l.errors.regions[l.errors.current])
+
+	case l.search.matches != 0:
+		l.errors.current = -1
+		l.search.current = min(l.search.current, l.search.matches-1)
+		l.view.Highlight(matchRegion(l.search.current))
+
+	default:
+		l.errors.current = -1
+	}
+
+	l.updateTitle()
+}
+
+// renderLine translates the ANSI colours of line into tview tags. Error lines
+// and lines with search matches lose their ANSI colours instead, error lines
+// are coloured red and each match is wrapped in a region so that it can be
+// highlighted and scrolled to. Their colours are reset before the newline as
+// tview carries tags over onto the following lines.
+func (l *logsPane) renderLine(line string) string {
+	plain, newline := strings.CutSuffix(logscan.StripANSI(line), "\n")
+	isError := l.errors.rules.Match(plain)
+
+	var locations [][]int
+	if l.search.pattern != nil {
+		for _, location := range l.search.pattern.FindAllStringIndex(plain, -1) {
+			if location[0] != location[1] {
+				locations = append(locations, location)
+			}
+		}
+	}
+
+	if !isError && len(locations) == 0 {
+		return tview.TranslateANSI(tview.Escape(line))
+	}
+
+	colour := "[-:-:-]"
+	if isError {
+		colour = colours.Tag(theme.ERROR_LINE, "", "b")
+	}
+
+	// Without colour matches are underlined to tell them apart
+	highlight := colours.Tag(theme.MATCH, theme.MATCH_BACKGROUND, "")
+	if colours.NoColor {
+		highlight = "[::u]"
+	}
+
+	var text strings.Builder
+	text.WriteString(colour)
+	last := 0
+	if isError {
+		if len(locations) != 0 && locations[0][0] == 0 {
+			l.errors.regions = append(l.errors.regions, matchRegion(l.search.matches))
+		} else {
+			region := errorRegion(len(l.errors.regions))
+			last = len(plain)
+			if len(locations) != 0 {
+				last = locations[0][0]
+			}
+
+			fmt.Fprintf(&text, `["%s"]%s[""]`, region, tview.Escape(plain[:last]))
+			l.errors.regions = append(l.errors.regions, region)
+		}
+	}
+
+	for _, location := range locations {
+		text.WriteString(tview.Escape(plain[last:location[0]]))
+		fmt.Fprintf(&text, `["%s"]%s%s%s[""]`, matchRegion(l.search.matches), highlight, tview.Escape(plain[location[0]:location[1]]), colour)
+		l.search.matches++
+		last = location[1]
+	}
+
+	text.WriteString(tview.Escape(plain[last:]) + "[-:-:-]")
+	if newline {
+		text.WriteString("\n")
+	}
+
+	return text.String()
 }
@@ -0,0 +1,102 @@
+package tui
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jedrw/circlog/logscan"
+	"github.com/jedrw/circlog/theme"
+	"github.com/rivo/tview"
+)
+
+// drawLogs renders raw in a logs pane and returns the foreground colour and
+// attributes of the first character of every line drawn
+func drawLogs(t *testing.T, raw string, search string) []tcell.Style {
+	t.Helper()
+
+	var err error
+	colours, err = theme.New("dark", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rules, err := logscan.NewErrorRules(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	view := tview.NewTextView().SetDynamicColors(true).SetRegions(true)
+	l := logsPane{view: view, errors: logErrors{rules: rules, current: -1}}
+	if search != "" {
+		l.search.pattern = regexp.MustCompile(search)
+	}
+
+	l.raw = raw
+	l.render()
+
+	screen := tcell.NewSimulationScreen("")
+	err = screen.Init()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	defer screen.Fini()
+	screen.SetSize(80, 10)
+	view.SetRect(0, 0, 80, 10)
+	view.Draw(screen)
+
+	var styles []tcell.Style
+	for y := range strings.Count(raw, "\n") {
+		_, _, style, _ := screen.GetContent(0, y)
+		styles = append(styles, style)
+	}
+
+	return styles
+}
+
+func TestRenderResetsColoursAfterLine(t *testing.T) {
+	tests := []struct {
+		name      string
+		raw       string
+		search    string
+		wantError []bool
+	}{
+		{
+			name:      "error line",
+			raw:       "ok line\npanic: boom\nnormal after\n",
+			wantError: []bool{false, true, false},
+		},
+		{
+			name:      "consecutive error lines",
+			raw:       "panic: boom\n--- FAIL: TestA\nnormal after\n",
+			wantError: []bool{true, true, false},
+		},
+		{
+			name:      "line with a search match",
+			raw:       "ok line\nbefore boom after\nnormal after\n",
+			search:    "boom",
+			wantError: []bool{false, false, false},
+		},
+		{
+			name:      "error line ending in a search match",
+			raw:       "panic: boom\nnormal after\n",
+			search:    "boom",
+			wantError: []bool{true, false},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			styles := drawLogs(t, test.raw, test.search)
+			for i, wantError := range test.wantError {
+				foreground, _, attributes := styles[i].Decompose()
+				isError := foreground == colours.Colour(theme.ERROR_LINE) && attributes&tcell.AttrBold != 0
+				if isError != wantError {
+					t.Errorf("line %d: expected error style %t, got foreground %v and attributes %v", i, wantError, foreground, attributes)
+				}
+			}
+		})
+	}
+}
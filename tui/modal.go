@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+const flashDuration = 3 * time.Second
+
+// showModal shows content centred above the rest of the TUI and focuses it
+// until hideModal is called. A width or height of 0 fills most of the screen.
+func (cTui *CirclogTui) showModal(name string, content tview.Primitive, width int, height int) {
+	cTui.modalReturn = cTui.app.GetFocus()
+	cTui.modal = name
+	cTui.modalContent = content
+
+	column := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(content, height, modalProportion(height), true).
+		AddItem(nil, 0, 1, false)
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(column, width, modalProportion(width), true).
+		AddItem(nil, 0, 1, false)
+
+	cTui.pages.AddPage(name, modal, true, true)
+	cTui.app.SetFocus(content)
+}
+
+func modalProportion(size int) int {
+	if size == 0 {
+		return 10
+	}
+
+	return 0
+}
+
+func (cTui *CirclogTui) hideModal(name string) {
+	cTui.pages.RemovePage(name)
+	cTui.modal = ""
+	cTui.modalContent = nil
+	if cTui.modalReturn != nil {
+		cTui.app.SetFocus(cTui.modalReturn)
+	}
+}
+
+// flash briefly shows message in the title of the TUI
+func (cTui *CirclogTui) flash(message string) {
+	cTui.layout.SetTitle(" circlog - " + message + " ")
+	if cTui.flashTimer != nil {
+		cTui.flashTimer.Stop()
+	}
+
+	cTui.flashTimer = time.AfterFunc(flashDuration, func() {
+		cTui.app.QueueUpdateDraw(func() {
+			cTui.layout.SetTitle(" circlog ")
+		})
+	})
+}
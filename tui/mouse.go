@@ -0,0 +1,209 @@
+package tui
+
+import (
+	"slices"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jedrw/circlog/circleci"
+	"github.com/rivo/tview"
+)
+
+// drillOrder returns the panes in the order they are focused when drilling
+// down from a pipeline to the logs of a step
+func (cTui *CirclogTui) drillOrder() []tview.Primitive {
+	return []tview.Primitive{
+		cTui.pipelines.table,
+		cTui.workflows.table,
+		cTui.jobs.table,
+		cTui.steps.tree,
+		cTui.logs.view,
+	}
+}
+
+// captureMouse keeps the mouse consistent with the keyboard. Clicking a pane
+// before the focused one backs out to it as Esc would, while panes after it are
+// only reached by opening a row, which takes a double click.
+func (cTui *CirclogTui) captureMouse(event *tcell.EventMouse, action tview.MouseAction) (*tcell.EventMouse, tview.MouseAction) {
+	if action == tview.MouseMove {
+		return event, action
+	}
+
+	x, y := event.Position()
+	if cTui.modalContent != nil {
+		if inRect(cTui.modalContent, x, y) {
+			return event, action
+		}
+
+		if action == tview.MouseLeftClick {
+			cTui.hideModal(cTui.modal)
+		}
+
+		return nil, action
+	}
+
+	focus := cTui.app.GetFocus()
+	if focus == cTui.logs.prompt.input {
+		if inRect(focus, x, y) {
+			return event, action
+		}
+
+		return nil, action
+	}
+
+	panes := cTui.drillOrder()
+	current := slices.Index(panes, focus)
+	target := slices.IndexFunc(panes, func(pane tview.Primitive) bool {
+		return inRect(pane, x, y)
+	})
+
+	switch action {
+
+	case tview.MouseScrollUp:
+		if target == len(panes)-1 && current == target && cTui.logs.autoScroll {
+			cTui.logs.disableAutoScroll(cTui)
+		}
+
+		return event, action
+
+	case tview.MouseScrollDown:
+		return event, action
+
+	case tview.MouseLeftDown, tview.MouseLeftUp, tview.MouseLeftClick, tview.MouseLeftDoubleClick:
+
+	default:
+		return nil, action
+	}
+
+	if current < 0 {
+		return cTui.clickInput(focus, target, event, action)
+	}
+
+	pane, _ := cTui.focusedPane()
+	switch {
+
+	case inRect(cTui.projectSelect, x, y):
+		if action == tview.MouseLeftDown {
+			cTui.runAction(pane, actionSwitchProject)
+		}
+
+		return event, action
+
+	case inRect(cTui.branchSelect, x, y):
+		if action == tview.MouseLeftDown {
+			cTui.runAction(pane, actionBranchSelect)
+		}
+
+		return event, action
+	}
+
+	for action == tview.MouseLeftDown && target >= 0 && target < current {
+		pane.runAction(cTui, actionBack)
+		previous := slices.Index(panes, cTui.app.GetFocus())
+		if previous < 0 || previous >= current {
+			break
+		}
+
+		current = previous
+		pane, _ = cTui.focusedPane()
+	}
+
+	if target < 0 || target != current {
+		return nil, action
+	}
+
+	if panes[target] == cTui.steps.tree {
+		return cTui.clickStep(y, event, action)
+	}
+
+	if table, ok := panes[target].(*tview.Table); ok && action == tview.MouseLeftDoubleClick {
+		table.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), cTui.setFocus)
+		return nil, action
+	}
+
+	return event, action
+}
+
+// clickInput handles clicks while the project or branch is being entered.
+// Clicking the pipelines submits the input as Enter would.
+func (cTui *CirclogTui) clickInput(focus tview.Primitive, target int, event *tcell.EventMouse, action tview.MouseAction) (*tcell.EventMouse, tview.MouseAction) {
+	x, y := event.Position()
+	if inRect(cTui.projectSelect, x, y) || inRect(cTui.branchSelect, x, y) {
+		return event, action
+	}
+
+	if target == 0 && action == tview.MouseLeftClick && (focus == cTui.projectSelect || focus == cTui.branchSelect) {
+		focus.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), cTui.setFocus)
+	}
+
+	return nil, action
+}
+
+// clickStep expands or collapses a step when it is clicked and opens the logs
+// of an action when it is double clicked
+func (cTui *CirclogTui) clickStep(y int, event *tcell.EventMouse, action tview.MouseAction) (*tcell.EventMouse, tview.MouseAction) {
+	tree := cTui.steps.tree
+	node := nodeAt(tree, y)
+	if node == nil {
+		return nil, action
+	}
+
+	switch action {
+
+	case tview.MouseLeftClick:
+		if _, ok := node.GetReference().(circleci.Step); ok {
+			cTui.steps.toggleStep(node)
+		} else if node.GetReference() != nil {
+			tree.SetCurrentNode(node)
+		}
+
+	case tview.MouseLeftDoubleClick:
+		if _, ok := node.GetReference().(circleci.Action); ok {
+			tree.SetCurrentNode(node)
+			tree.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), cTui.setFocus)
+		}
+
+	default:
+		return event, action
+	}
+
+	return nil, action
+}
+
+func (cTui *CirclogTui) setFocus(primitive tview.Primitive) {
+	cTui.app.SetFocus(primitive)
+}
+
+// nodeAt returns the node of tree drawn on row y of the screen, assuming the
+// root itself is not drawn
+func nodeAt(tree *tview.TreeView, y int) *tview.TreeNode {
+	root := tree.GetRoot()
+	if root == nil {
+		return nil
+	}
+
+	var visible []*tview.TreeNode
+	var walk func(node *tview.TreeNode)
+	walk = func(node *tview.TreeNode) {
+		for _, child := range node.GetChildren() {
+			visible = append(visible, child)
+			if child.IsExpanded() {
+				walk(child)
+			}
+		}
+	}
+
+	walk(root)
+
+	_, top, _, _ := tree.GetInnerRect()
+	row := y - top + tree.GetScrollOffset()
+	if row < 0 || row >= len(visible) {
+		return nil
+	}
+
+	return visible[row]
+}
+
+func inRect(primitive tview.Primitive, x int, y int) bool {
+	left, top, width, height := primitive.GetRect()
+	return x >= left && x < left+width && y >= top && y < top+height
+}
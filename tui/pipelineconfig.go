@@ -0,0 +1,132 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jedrw/circlog/circleci"
+	"github.com/jedrw/circlog/theme"
+	"github.com/rivo/tview"
+)
+
+type configVariant struct {
+	name string
+	text func(pipelineConfig circleci.PipelineConfig) string
+}
+
+// configVariants are the configs of a pipeline in the order they are cycled
+// through
+var configVariants = []configVariant{
+	{"source", func(pipelineConfig circleci.PipelineConfig) string { return pipelineConfig.Source }},
+	{"compiled", func(pipelineConfig circleci.PipelineConfig) string { return pipelineConfig.Compiled }},
+	{"setup", func(pipelineConfig circleci.PipelineConfig) string { return pipelineConfig.SetupConfig }},
+	{"compiled setup", func(pipelineConfig circleci.PipelineConfig) string { return pipelineConfig.CompiledSetupConfig }},
+}
+
+var (
+	yamlKeyPattern     = regexp.MustCompile(`^(\s*(?:- +)*)([^\s#'"-][^:#]*|"[^"]*"|'[^']*'):(\s|$)`)
+	yamlCommentPattern = regexp.MustCompile(`(^|\s)#.*$`)
+)
+
+func (cTui *CirclogTui) openPipelineConfig(pipeline circleci.Pipeline) {
+	go func() {
+		pipelineConfig, err := circleci.GetPipelineConfig(cTui.config, pipeline.Id)
+		cTui.app.QueueUpdateDraw(func() {
+			if err != nil {
+				cTui.flash(fmt.Sprintf("Could not get config: %s", err))
+				return
+			}
+
+			cTui.showPipelineConfig(pipeline, pipelineConfig)
+		})
+	}()
+}
+
+// showPipelineConfig shows the configs of pipeline, the config action cycling
+// through those it has
+func (cTui *CirclogTui) showPipelineConfig(pipeline circleci.Pipeline, pipelineConfig circleci.PipelineConfig) {
+	var variants []configVariant
+	for _, variant := range configVariants {
+		if variant.text(pipelineConfig) != "" {
+			variants = append(variants, variant)
+		}
+	}
+
+	if len(variants) == 0 {
+		cTui.flash(fmt.Sprintf("Pipeline %d has no config", pipeline.Number))
+		return
+	}
+
+	view := tview.NewTextView().SetDynamicColors(true)
+	view.SetBorder(true).SetBorderPadding(0, 0, 1, 1)
+	view.SetBackgroundColor(tcell.ColorDefault)
+
+	current := 0
+	show := func() {
+		view.SetTitle(fmt.Sprintf(" CONFIG - Pipeline %d - %s ", pipeline.Number, variants[current].name))
+		view.SetText(numberLines(highlightYaml(variants[current].text(pipelineConfig))))
+		view.ScrollToBeginning()
+	}
+
+	show()
+
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc || event.Rune() == 'q' {
+			cTui.hideModal("config")
+
+			return nil
+		}
+
+		if cTui.keys.match(event, []action{actionShowConfig}) == actionShowConfig {
+			current = (current + 1) % len(variants)
+			show()
+
+			return nil
+		}
+
+		return event
+	})
+
+	cTui.showModal("config", view, 0, 0)
+}
+
+// highlightYaml escapes each line of source and colours its keys and comments
+func highlightYaml(source string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimRight(source, "\n"), "\n") {
+		var comment string
+		if match := yamlCommentPattern.FindStringIndex(line); match != nil {
+			line, comment = line[:match[0]], line[match[0]:]
+		}
+
+		var highlighted strings.Builder
+		if match := yamlKeyPattern.FindStringSubmatchIndex(line); match != nil {
+			highlighted.WriteString(tview.Escape(line[:match[4]]))
+			highlighted.WriteString(colours.Tag(theme.ACCENT, "", "") + tview.Escape(line[match[4]:match[5]]) + "[-]")
+			highlighted.WriteString(tview.Escape(line[match[5]:]))
+		} else {
+			highlighted.WriteString(tview.Escape(line))
+		}
+
+		if comment != "" {
+			highlighted.WriteString(colours.Tag(theme.MUTED, "", "") + tview.Escape(comment) + "[-]")
+		}
+
+		lines = append(lines, highlighted.String())
+	}
+
+	return lines
+}
+
+func numberLines(lines []string) string {
+	width := len(fmt.Sprint(len(lines)))
+
+	var text strings.Builder
+	for i, line := range lines {
+		fmt.Fprintf(&text, "%s%*d[-]  %s\n", colours.Tag(theme.MUTED, "", ""), width, i+1, line)
+	}
+
+	return strings.TrimSuffix(text.String(), "\n")
+}
@@ -7,25 +7,61 @@ import (
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/jedrw/circlog/circleci"
+	"github.com/jedrw/circlog/git"
+	"github.com/jedrw/circlog/theme"
 	"github.com/rivo/tview"
 )
 
+// erroredMarker shows pipelines whose config could not be compiled
+const erroredMarker = "✗ "
+
+const maxPipelineColumnWidth = 50
+
+type pipelineColumn struct {
+	header string
+	value  func(pipeline circleci.Pipeline) string
+}
+
+// pipelineColumns are the optional columns of the pipelines table
+var pipelineColumns = map[string]pipelineColumn{
+	"subject": {"Subject", func(pipeline circleci.Pipeline) string { return pipeline.Vcs.Commit.Subject }},
+	"actor":   {"Actor", func(pipeline circleci.Pipeline) string { return pipeline.Trigger.Actor.Login }},
+}
+
+func checkPipelineColumns(columns []string) error {
+	for _, column := range columns {
+		if _, ok := pipelineColumns[column]; !ok {
+			return fmt.Errorf("unknown pipeline column %q", column)
+		}
+	}
+
+	return nil
+}
+
 type pipelinesPane struct {
 	table       *tview.Table
+	columns     []string
+	watchlist   *watchlist
+	pager       *circleci.Pager[circleci.Pipeline]
 	numPages    int
 	watchCtx    context.Context
 	watchCancel context.CancelFunc
 }
 
-func (cTui *CirclogTui) newPipelinesPane() pipelinesPane {
+func (cTui *CirclogTui) newPipelinesPane(columns []string) pipelinesPane {
 	table := tview.NewTable()
 	table.SetTitle(" PIPELINES ")
 	table.SetBackgroundColor(tcell.ColorDefault)
 	table.SetBorder(true)
-	table.SetBorderColor(tcell.ColorGrey)
+	table.SetBorderColor(colours.Colour(theme.BORDER))
 	table.SetSelectable(true, false).SetFixed(1, 0).SetSeparator(tview.Borders.Vertical)
 
-	for column, header := range []string{"Number", "Branch/Tag", "Start", "Trigger"} {
+	headers := []string{"Number", "Branch/Tag", "Start", "Trigger"}
+	for _, column := range columns {
+		headers = append(headers, pipelineColumns[column].header)
+	}
+
+	for column, header := range headers {
 		table.SetCell(0, column, tview.NewTableCell(header).SetStyle(tcell.StyleDefault.Attributes(tcell.AttrBold)).SetSelectable(false))
 	}
 
@@ -36,72 +72,34 @@ func (cTui *CirclogTui) newPipelinesPane() pipelinesPane {
 
 		case circleci.Pipeline:
 			cTui.state.pipeline = cellRef
-			workflows, nextPageToken, _ := circleci.GetPipelineWorkflows(cTui.config, cTui.state.pipeline.Id, 1, "")
-			cTui.workflows.populateWorkflowsTable(workflows, nextPageToken)
+			cTui.workflows.loadFirstPage(cTui)
 			cTui.app.SetFocus(cTui.workflows.table)
 
 		case string:
 			if cell.Text == "..." {
 				cTui.pipelines.restartWatcher(cTui, func() {
-					nextPageToken := cell.GetReference().(string)
-					newPipelines, nextPageToken, _ := circleci.GetProjectPipelines(cTui.config, 1, nextPageToken)
-					cTui.pipelines.addPipelinesToTable(newPipelines, table.GetRowCount()-1, nextPageToken)
-					cTui.pipelines.numPages++
+					err := cTui.pipelines.loadNextPage()
+					if err != nil {
+						cTui.flash(fmt.Sprintf("Could not load pipelines: %s", err))
+					}
 				})
 			}
 		}
 	})
 
 	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		if event.Key() == tcell.KeyEsc {
-			cTui.clearAll()
-			table.SetBorderColor(tcell.ColorGrey)
-			cTui.config.Project = ""
-			cTui.config.Branch = ""
-			cTui.app.SetFocus(cTui.projectSelect)
-
-			return event
-		}
-
-		switch event.Rune() {
-
-		case 'v':
-			cell := table.GetCell(table.GetSelection())
-			cellRef := cell.GetReference()
-			switch cellRef := cellRef.(type) {
-			case circleci.Pipeline:
-				if cellRef.Vcs.Branch != "" {
-					cTui.pipelines.restartWatcher(cTui, func() {
-						cTui.pipelines.clear()
-						cTui.pipelines.numPages = 1
-						cTui.config.Branch = cellRef.Vcs.Branch
-						cTui.branchSelect.SetText(cTui.config.Branch)
-						pipelines, nextPageToken, _ := circleci.GetProjectPipelines(cTui.config, 1, "")
-						cTui.pipelines.populateTable(pipelines, nextPageToken)
-						cTui.pipelines.table.ScrollToBeginning()
-					})
-				}
-			}
-
-		case 'b':
-			cTui.clearAll()
-			table.SetBorderColor(tcell.ColorGrey)
-			cTui.config.Branch = ""
-			cTui.app.SetFocus(cTui.branchSelect)
-
-		case 'd':
-			cTui.watchCancelAll()
-			cTui.app.Stop()
-			fmt.Printf("circlog pipelines %s\n", cTui.config.Project)
+		if cTui.runAction(&cTui.pipelines, cTui.keys.match(event, pipelinesActions)) {
+			return nil
 		}
 
 		return event
 	})
 
 	table.SetFocusFunc(func() {
+		cTui.saveSession()
 		cTui.pipelines.restartWatcher(cTui, func() {
-			table.SetBorderColor(tcell.ColorDefault)
-			cTui.paneControls.SetText("Filter by branch\t[V]")
+			table.SetBorderColor(colours.Colour(theme.FOCUSED_BORDER))
+			cTui.paneControls.SetText(cTui.keys.help(pipelinesHelp))
 		})
 	})
 
@@ -109,12 +107,63 @@ func (cTui *CirclogTui) newPipelinesPane() pipelinesPane {
 
 	return pipelinesPane{
 		table:       table,
+		columns:     columns,
+		watchlist:   cTui.watchlist,
 		numPages:    1,
 		watchCtx:    watchCtx,
 		watchCancel: watchCancel,
 	}
 }
 
+// jumpToHeadPipeline selects the pipeline for the commit checked out in the
+// working directory when it is a checkout of the project
+func (cTui *CirclogTui) jumpToHeadPipeline() {
+	repository, err := git.RepositoryName()
+	if err != nil || repository != cTui.config.Project {
+		return
+	}
+
+	revision, err := git.HeadRevision()
+	if err != nil {
+		return
+	}
+
+	// Only the branch checked out can have a pipeline for its head
+	config := cTui.config
+	branch, err := git.CurrentBranch()
+	if err != nil || branch == "" || (config.Branch != "" && config.Branch != branch) {
+		return
+	}
+
+	config.Branch = branch
+	// Only the first page is loaded into the table, so there is no point
+	// searching further
+	pipeline, err := circleci.GetPipelineByRevision(config, revision, 1)
+	if err != nil {
+		return
+	}
+
+	cTui.app.QueueUpdateDraw(func() {
+		if cTui.app.GetFocus() != cTui.pipelines.table || cTui.config.Project != pipelineProject(pipeline) {
+			return
+		}
+
+		// Leave the selection alone once it has been moved
+		table := cTui.pipelines.table
+		if selected, _ := table.GetSelection(); selected > 1 {
+			return
+		}
+
+		for row := 1; row < table.GetRowCount(); row++ {
+			cellRef, ok := table.GetCell(row, 0).GetReference().(circleci.Pipeline)
+			if ok && cellRef.Id == pipeline.Id {
+				table.Select(row, 0)
+				return
+			}
+		}
+	})
+}
+
 func (p *pipelinesPane) watchPipelines(ctx context.Context, cTui *CirclogTui) {
 	pipelinesChan := make(chan []circleci.Pipeline)
 	nextPageTokenChan := make(chan string)
@@ -152,6 +201,105 @@ func (p *pipelinesPane) restartWatcher(cTui *CirclogTui, fn func()) {
 	go p.watchPipelines(p.watchCtx, cTui)
 }
 
+func (p *pipelinesPane) runAction(cTui *CirclogTui, action action) bool {
+	switch action {
+
+	case actionBack:
+		cTui.clearAll()
+		p.table.SetBorderColor(colours.Colour(theme.BORDER))
+		cTui.config.Project = ""
+		cTui.config.Branch = ""
+		cTui.app.SetFocus(cTui.projectSelect)
+
+	case actionFilterBranch:
+		cell := p.table.GetCell(p.table.GetSelection())
+		cellRef := cell.GetReference()
+		switch cellRef := cellRef.(type) {
+		case circleci.Pipeline:
+			if cellRef.Vcs.Branch != "" {
+				p.restartWatcher(cTui, func() {
+					cTui.config.Branch = cellRef.Vcs.Branch
+					cTui.branchSelect.SetText(cTui.config.Branch)
+					p.loadFirstPage(cTui)
+					p.table.ScrollToBeginning()
+				})
+			}
+		}
+
+	case actionWatch:
+		cell := p.table.GetCell(p.table.GetSelection())
+		cellRef, ok := cell.GetReference().(circleci.Pipeline)
+		if ok {
+			p.restartWatcher(cTui, func() {
+				cTui.toggleWatch(watchedPipeline(cTui.config.Project, cellRef))
+			})
+		}
+
+	case actionShowInfo:
+		cell := p.table.GetCell(p.table.GetSelection())
+		cellRef, ok := cell.GetReference().(circleci.Pipeline)
+		if ok {
+			cTui.showInfo(" PIPELINE ", pipelineInfo(cellRef))
+		}
+
+	case actionShowConfig:
+		cell := p.table.GetCell(p.table.GetSelection())
+		cellRef, ok := cell.GetReference().(circleci.Pipeline)
+		if ok {
+			cTui.openPipelineConfig(cellRef)
+		}
+
+	case actionBranchSelect:
+		cTui.clearAll()
+		p.table.SetBorderColor(colours.Colour(theme.BORDER))
+		cTui.config.Branch = ""
+		cTui.app.SetFocus(cTui.branchSelect)
+
+	case actionYank:
+		cell := p.table.GetCell(p.table.GetSelection())
+		cellRef, ok := cell.GetReference().(circleci.Pipeline)
+		if ok {
+			cTui.openYankMenu(cTui.pipelineYankItems(cellRef))
+		}
+
+	case actionBrowse:
+		cell := p.table.GetCell(p.table.GetSelection())
+		cellRef, ok := cell.GetReference().(circleci.Pipeline)
+		if ok {
+			cTui.openInBrowser(circleci.PipelineWebUrl(cTui.config, cellRef.Number))
+		}
+
+	case actionDump:
+		cTui.watchCancelAll()
+		cTui.app.Stop()
+		fmt.Println(cTui.pipelinesCommand())
+
+	default:
+		return false
+	}
+
+	return true
+}
+
+func (p *pipelinesPane) loadFirstPage(cTui *CirclogTui) {
+	p.pager = circleci.ProjectPipelinesPager(cTui.config, circleci.PipelineFilter{}, "")
+	p.numPages = 1
+	pipelines, _ := p.pager.NextPage()
+	p.populateTable(pipelines, p.pager.NextPageToken())
+}
+
+func (p *pipelinesPane) loadNextPage() error {
+	pipelines, err := p.pager.NextPage()
+	if err != nil {
+		return err
+	}
+
+	p.addPipelinesToTable(pipelines, p.table.GetRowCount()-1, p.pager.NextPageToken())
+	p.numPages++
+
+	return nil
+}
+
 func (p *pipelinesPane) populateTable(pipelines []circleci.Pipeline, nextPageToken string) {
 	p.clear()
 	p.addPipelinesToTable(pipelines, p.table.GetRowCount(), nextPageToken)
@@ -160,21 +308,38 @@ func (p *pipelinesPane) populateTable(pipelines []circleci.Pipeline, nextPageTok
 func (p *pipelinesPane) addPipelinesToTable(pipelines []circleci.Pipeline, startRow int, nextPageToken string) {
 	if len(pipelines) != 0 {
 		for row, pipeline := range pipelines {
-			for column, attr := range []string{fmt.Sprint(pipeline.Number), branchOrTag(pipeline), pipeline.CreatedAt.Local().Format(time.RFC822Z), pipeline.Trigger.Type} {
+			number := fmt.Sprint(pipeline.Number)
+			if pipeline.State == circleci.ERRORED {
+				number = erroredMarker + number
+			}
+
+			if p.watchlist.isWatched(pipeline.Id) {
+				number = watchedMarker + number
+			}
+
+			attrs := []string{number, branchOrTag(pipeline), pipeline.CreatedAt.Local().Format(time.RFC822Z), pipeline.Trigger.Type}
+			for _, column := range p.columns {
+				attrs = append(attrs, tview.Escape(pipelineColumns[column].value(pipeline)))
+			}
+
+			for column, attr := range attrs {
 				cell := tview.NewTableCell(attr).SetStyle(styleForStatus(pipeline.State))
 				cell.SetReference(pipeline)
+				if column >= len(attrs)-len(p.columns) {
+					cell.SetMaxWidth(maxPipelineColumnWidth)
+				}
+
 				p.table.SetCell(row+startRow, column, cell)
 			}
 		}
 
 		if nextPageToken != "" {
-			cell := tview.NewTableCell("...")
-			cell.SetReference(nextPageToken)
-			p.table.SetCell(p.table.GetRowCount(), 0, cell)
+			row := p.table.GetRowCount()
+			p.table.SetCell(row, 0, loadMoreCell(p.table, row, nextPageToken))
 		}
 
 	} else {
-		cell := tview.NewTableCell("None").SetStyle(tcell.StyleDefault.Background(tcell.ColorDefault).Foreground(tcell.ColorDarkGray))
+		cell := tview.NewTableCell("None").SetStyle(colours.Style(theme.MUTED))
 		p.table.SetCell(1, 0, cell)
 	}
 }
@@ -2,7 +2,6 @@ package tui
 
 import (
 	"github.com/gdamore/tcell/v2"
-	"github.com/jedrw/circlog/circleci"
 	"github.com/rivo/tview"
 )
 
@@ -15,14 +14,14 @@ func (cTui *CirclogTui) initProjectSelect() {
 
 	cTui.projectSelect.SetLabel("Project: ").SetDoneFunc(func(key tcell.Key) {
 		cTui.config.Project = cTui.projectSelect.GetText()
-		pipelines, nextPageToken, _ := circleci.GetProjectPipelines(cTui.config, 1, "")
-		cTui.pipelines.populateTable(pipelines, nextPageToken)
+		cTui.pipelines.loadFirstPage(cTui)
 		cTui.pipelines.table.ScrollToBeginning()
 		cTui.app.SetFocus(cTui.pipelines.table)
 	})
 
 	cTui.projectSelect.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		if event.Key() == tcell.KeyEsc {
+		// Keys for characters are typed rather than acted on
+		if event.Key() != tcell.KeyRune && cTui.keys.match(event, inputActions) == actionQuit {
 			cTui.app.Stop()
 		}
 
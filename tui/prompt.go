@@ -0,0 +1,73 @@
+package tui
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// logsPrompt is the single line input shown below the logs, shared by
+// everything in the logs pane that asks for text
+type logsPrompt struct {
+	input *tview.InputField
+	name  string
+	regex *bool
+	done  func(text string)
+}
+
+func (cTui *CirclogTui) newPromptInput() *tview.InputField {
+	input := tview.NewInputField()
+	input.SetBackgroundColor(tcell.ColorDefault)
+	input.SetFieldBackgroundColor(tcell.ColorDefault)
+	input.SetLabelStyle(
+		tcell.StyleDefault.Background(tcell.ColorReset).Foreground(tcell.ColorReset),
+	)
+
+	input.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		prompt := &cTui.logs.prompt
+		if event.Key() == tcell.KeyCtrlR && prompt.regex != nil {
+			*prompt.regex = !*prompt.regex
+			prompt.updateLabel()
+
+			return nil
+		}
+
+		return event
+	})
+
+	input.SetDoneFunc(func(key tcell.Key) {
+		cTui.logs.closePrompt(cTui)
+		if key == tcell.KeyEnter {
+			cTui.logs.prompt.done(input.GetText())
+		}
+	})
+
+	return input
+}
+
+func (p *logsPrompt) updateLabel() {
+	switch {
+	case p.regex == nil:
+		p.input.SetLabel(p.name + ": ")
+	case *p.regex:
+		p.input.SetLabel(p.name + " (regex, Ctrl+R for text): ")
+	default:
+		p.input.SetLabel(p.name + " (text, Ctrl+R for regex): ")
+	}
+}
+
+// openPrompt asks for text below the logs, calling done with it once Enter is
+// pressed. When regex is set Ctrl+R toggles it.
+func (l *logsPane) openPrompt(cTui *CirclogTui, name string, text string, regex *bool, done func(text string)) {
+	l.prompt.name = name
+	l.prompt.regex = regex
+	l.prompt.done = done
+	l.prompt.updateLabel()
+	l.prompt.input.SetText(text)
+	l.layout.ResizeItem(l.prompt.input, 1, 0)
+	cTui.app.SetFocus(l.prompt.input)
+}
+
+func (l *logsPane) closePrompt(cTui *CirclogTui) {
+	l.layout.ResizeItem(l.prompt.input, 0, 0)
+	cTui.app.SetFocus(l.view)
+}
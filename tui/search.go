@@ -0,0 +1,68 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/jedrw/circlog/logscan"
+)
+
+type logSearch struct {
+	text    string
+	regex   bool
+	pattern *regexp.Regexp
+	matches int
+	current int
+}
+
+func matchRegion(match int) string {
+	return fmt.Sprintf("match-%d", match)
+}
+
+func (l *logsPane) openSearch(cTui *CirclogTui) {
+	l.openPrompt(cTui, "Search", l.search.text, &l.search.regex, func(text string) {
+		l.applySearch(text)
+	})
+}
+
+func (l *logsPane) applySearch(text string) {
+	l.search.text = text
+	if text == "" {
+		l.search.pattern = nil
+		l.render()
+		l.updateTitle()
+
+		return
+	}
+
+	pattern, err := logscan.CompilePattern(text, l.search.regex)
+	if err != nil {
+		l.view.SetTitle(fmt.Sprintf(" LOGS - Invalid search: %s ", err))
+
+		return
+	}
+
+	l.search.pattern = pattern
+	l.search.current = 0
+	l.errors.current = -1
+	l.render()
+
+	// While autoscrolling the next refresh would undo scrolling to the first
+	// match, so it is left to the next and previous match actions
+	if !l.autoScroll {
+		l.jumpToMatch(0)
+	}
+}
+
+// jumpToMatch moves the current match forwards or backwards by delta matches,
+// wrapping around at either end
+func (l *logsPane) jumpToMatch(delta int) {
+	if l.search.pattern == nil || l.search.matches == 0 {
+		return
+	}
+
+	l.errors.current = -1
+	l.search.current = (l.search.current + delta%l.search.matches + l.search.matches) % l.search.matches
+	l.view.Highlight(matchRegion(l.search.current)).ScrollToHighlight()
+	l.updateTitle()
+}
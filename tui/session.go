@@ -0,0 +1,293 @@
+package tui
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jedrw/circlog/circleci"
+	"github.com/jedrw/circlog/config"
+	"github.com/rivo/tview"
+)
+
+const sessionFile = "session.json"
+
+// maxOpenPages is how many further pages are loaded looking for a workflow or
+// job to open
+const maxOpenPages = 10
+
+// location is how far the TUI has been drilled into a project. Workflows and
+// jobs are found by ID or number when known, otherwise by name.
+type location struct {
+	Vcs          string          `json:"vcs"`
+	Org          string          `json:"organisation"`
+	Project      string          `json:"project"`
+	Branch       string          `json:"branch"`
+	Pipeline     int             `json:"pipeline,omitempty"`
+	WorkflowId   string          `json:"workflow_id,omitempty"`
+	WorkflowName string          `json:"workflow_name,omitempty"`
+	JobNumber    int64           `json:"job_number,omitempty"`
+	JobName      string          `json:"job_name,omitempty"`
+	Action       *actionLocation `json:"action,omitempty"`
+
+	follow bool
+}
+
+type actionLocation struct {
+	Step  int64 `json:"step"`
+	Index int64 `json:"index"`
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+// Open starts the TUI drilled down to a pipeline and optionally one of its
+// workflows, by ID or name, and one of its jobs, by number or name. With follow
+// set the logs of the job are followed.
+func (cTui *CirclogTui) Open(pipeline int, workflow string, job string, follow bool) {
+	target := location{Pipeline: pipeline, follow: follow}
+	if uuidPattern.MatchString(workflow) {
+		target.WorkflowId = workflow
+	} else {
+		target.WorkflowName = workflow
+	}
+
+	if number, err := strconv.ParseInt(job, 10, 64); err == nil {
+		target.JobNumber = number
+	} else {
+		target.JobName = job
+	}
+
+	cTui.open = &target
+}
+
+// Resume reopens the TUI where it was last left, unless a different project
+// was given
+func (cTui *CirclogTui) Resume() error {
+	path, err := config.FilePath(sessionFile)
+	if err != nil {
+		return err
+	}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	var session location
+	err = json.Unmarshal(b, &session)
+	if err != nil {
+		return fmt.Errorf("could not parse %s", path)
+	}
+
+	if session.Vcs != cTui.config.Vcs || session.Org != cTui.config.Org {
+		return nil
+	}
+
+	if cTui.config.Project == "" {
+		cTui.config.Project = session.Project
+		if cTui.config.Branch == "" {
+			cTui.config.Branch = session.Branch
+		}
+	}
+
+	if session.Project == cTui.config.Project {
+		cTui.open = &session
+	}
+
+	return nil
+}
+
+// currentLocation is everything opened on the way to the focused pane
+func (cTui *CirclogTui) currentLocation() location {
+	current := location{
+		Vcs:     cTui.config.Vcs,
+		Org:     cTui.config.Org,
+		Project: cTui.config.Project,
+		Branch:  cTui.config.Branch,
+	}
+
+	depth := slices.Index(cTui.drillOrder(), cTui.app.GetFocus())
+	if depth >= 1 {
+		current.Pipeline = cTui.state.pipeline.Number
+	}
+
+	if depth >= 2 {
+		current.WorkflowId = cTui.state.workflow.Id
+		current.WorkflowName = cTui.state.workflow.Name
+	}
+
+	if depth >= 3 {
+		current.JobNumber = cTui.state.job.JobNumber
+		current.JobName = cTui.state.job.Name
+	}
+
+	if depth >= 4 {
+		current.Action = &actionLocation{cTui.state.action.Step, cTui.state.action.Index}
+	}
+
+	return current
+}
+
+// saveSession remembers the focused pane so it can be resumed
+func (cTui *CirclogTui) saveSession() {
+	path, err := config.FilePath(sessionFile)
+	if err == nil {
+		var b []byte
+		b, err = json.MarshalIndent(cTui.currentLocation(), "", "  ")
+		if err == nil {
+			err = os.WriteFile(path, b, 0644)
+		}
+	}
+
+	if err != nil {
+		cTui.flash(fmt.Sprintf("Could not save session: %s", err))
+	}
+}
+
+// drillDown opens each item of target in turn, as if it had been selected,
+// stopping with a message at the first that cannot be found. Moving away from
+// the pipelines before they are fetched cancels it.
+func (cTui *CirclogTui) drillDown(target location) {
+	if target.Pipeline == 0 {
+		return
+	}
+
+	pipeline, err := circleci.GetPipelineByNumber(cTui.config, target.Pipeline)
+
+	cTui.app.QueueUpdateDraw(func() {
+		if cTui.app.GetFocus() != cTui.pipelines.table {
+			return
+		}
+
+		if err != nil {
+			cTui.flash(fmt.Sprintf("Could not open pipeline %d: %s", target.Pipeline, err))
+			return
+		}
+
+		if found, _ := cTui.openRow(cTui.pipelines.table, nil, func(cellRef any) bool {
+			ref, ok := cellRef.(circleci.Pipeline)
+			return ok && ref.Id == pipeline.Id
+		}); !found {
+			// Older than the first page, so open it directly
+			cTui.state.pipeline = pipeline
+			cTui.workflows.loadFirstPage(cTui)
+			cTui.app.SetFocus(cTui.workflows.table)
+		}
+
+		if target.WorkflowId == "" && target.WorkflowName == "" {
+			return
+		}
+
+		workflows := &cTui.workflows
+		found, err := cTui.openRow(workflows.table, func() error {
+			var err error
+			workflows.restartWatcher(cTui, func() {
+				err = workflows.loadNextPage()
+			})
+
+			return err
+		}, func(cellRef any) bool {
+			ref, ok := cellRef.(circleci.Workflow)
+			if target.WorkflowId != "" {
+				return ok && ref.Id == target.WorkflowId
+			}
+
+			return ok && ref.Name == target.WorkflowName
+		})
+		if err != nil {
+			cTui.flash(fmt.Sprintf("Could not load workflows: %s", err))
+			return
+		} else if !found {
+			cTui.flash(fmt.Sprintf("Workflow %s not found", cmp.Or(target.WorkflowName, target.WorkflowId)))
+			return
+		}
+
+		if target.JobNumber == 0 && target.JobName == "" {
+			return
+		}
+
+		jobs := &cTui.jobs
+		found, err = cTui.openRow(jobs.table, func() error {
+			var err error
+			jobs.restartWatcher(cTui, func() {
+				err = jobs.loadNextPage()
+			})
+
+			return err
+		}, func(cellRef any) bool {
+			ref, ok := cellRef.(circleci.Job)
+			if target.JobNumber != 0 {
+				return ok && ref.JobNumber == target.JobNumber
+			}
+
+			return ok && ref.Name == target.JobName
+		})
+		if err != nil {
+			cTui.flash(fmt.Sprintf("Could not load jobs: %s", err))
+			return
+		} else if !found {
+			cTui.flash(fmt.Sprintf("Job %s not found", cmp.Or(target.JobName, fmt.Sprint(target.JobNumber))))
+			return
+		}
+
+		if target.follow {
+			cTui.runAction(&cTui.steps, actionToggleFollow)
+			return
+		}
+
+		if target.Action == nil {
+			return
+		}
+
+		for _, step := range cTui.steps.tree.GetRoot().GetChildren() {
+			for _, node := range step.GetChildren() {
+				action, ok := node.GetReference().(circleci.Action)
+				if ok && action.Step == target.Action.Step && action.Index == target.Action.Index {
+					cTui.steps.tree.SetCurrentNode(node)
+					cTui.steps.tree.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), cTui.setFocus)
+					return
+				}
+			}
+		}
+
+		cTui.flash("Step not found")
+	})
+}
+
+// openRow selects the first row of table with a matching reference and opens
+// it as Enter would. Up to maxOpenPages further pages are loaded looking for it,
+// stopping early should a page fail to load or add no rows.
+func (cTui *CirclogTui) openRow(table *tview.Table, loadNextPage func() error, match func(cellRef any) bool) (bool, error) {
+	for pages := 0; ; pages++ {
+		for row := 1; row < table.GetRowCount(); row++ {
+			if match(table.GetCell(row, 0).GetReference()) {
+				table.Select(row, 0)
+				table.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), cTui.setFocus)
+				return true, nil
+			}
+		}
+
+		rows := table.GetRowCount()
+		if loadNextPage == nil || pages == maxOpenPages || table.GetCell(rows-1, 0).Text != "..." {
+			return false, nil
+		}
+
+		err := loadNextPage()
+		if err != nil {
+			return false, err
+		}
+
+		// The "..." row is replaced by the first row of the page
+		if table.GetRowCount() == rows && table.GetCell(rows-1, 0).Text == "..." {
+			return false, nil
+		}
+	}
+}
@@ -7,14 +7,20 @@ import (
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/jedrw/circlog/circleci"
+	"github.com/jedrw/circlog/theme"
 	"github.com/rivo/tview"
 )
 
 type stepsPane struct {
 	tree        *tview.TreeView
 	follow      bool
+	collapsed   map[string]bool
 	watchCtx    context.Context
 	watchCancel context.CancelFunc
+	// selected is the last action the user moved to, kept while its step is
+	// collapsed and the tree has moved its current node elsewhere
+	selected *circleci.Action
+	hiding   bool
 }
 
 func (cTui *CirclogTui) newStepsPane() stepsPane {
@@ -22,7 +28,7 @@ func (cTui *CirclogTui) newStepsPane() stepsPane {
 	tree.SetTitle(" STEPS - Follow Disabled ")
 	tree.SetBackgroundColor(tcell.ColorDefault)
 	tree.SetBorder(true)
-	tree.SetBorderColor(tcell.ColorGrey)
+	tree.SetBorderColor(colours.Colour(theme.BORDER))
 	tree.SetSelectedFunc(func(node *tview.TreeNode) {
 		cTui.logs.restartWatcher(cTui, func() {
 			cTui.steps.restartWatcher(cTui, func() {
@@ -33,52 +39,42 @@ func (cTui *CirclogTui) newStepsPane() stepsPane {
 		cTui.app.SetFocus(cTui.logs.view)
 	})
 
-	tree.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		switch event.Key() {
-
-		case tcell.KeyUp:
-			if cTui.steps.follow {
-				cTui.steps.restartWatcher(cTui, func() {
-					cTui.steps.follow = false
-					cTui.steps.tree.SetTitle(" STEPS - Follow Disabled ")
-				})
-			}
-
-		case tcell.KeyEsc:
-			cTui.logs.watchCancel()
-			cTui.steps.watchCancel()
-			cTui.logs.view.Clear()
-			cTui.steps.clear()
-			tree.SetBorderColor(tcell.ColorGrey)
-			cTui.app.SetFocus(cTui.jobs.table)
-
-			return event
-
+	tree.SetChangedFunc(func(node *tview.TreeNode) {
+		action, ok := node.GetReference().(circleci.Action)
+		if !ok {
+			return
 		}
 
-		switch event.Rune() {
+		// The tree moves off a hidden current node by itself, which is not the
+		// user selecting another action
+		if cTui.steps.hiding {
+			cTui.steps.hiding = false
+			return
+		}
 
-		case 'f':
-			toggleFollow(cTui)
-			cTui.app.SetFocus(cTui.logs.view)
+		cTui.steps.selected = &action
+	})
 
-		case 'b':
-			cTui.clearAll()
-			cTui.config.Branch = ""
-			cTui.app.SetFocus(cTui.branchSelect)
+	tree.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyUp && cTui.steps.follow {
+			cTui.steps.restartWatcher(cTui, func() {
+				cTui.steps.follow = false
+				cTui.steps.tree.SetTitle(" STEPS - Follow Disabled ")
+			})
+		}
 
-		case 'd':
-			cTui.app.Stop()
-			fmt.Printf("circlog steps %s -j %d\n", cTui.config.Project, cTui.state.job.JobNumber)
+		if cTui.runAction(&cTui.steps, cTui.keys.match(event, stepsActions)) {
+			return nil
 		}
 
 		return event
 	})
 
 	tree.SetFocusFunc(func() {
+		cTui.saveSession()
 		cTui.steps.restartWatcher(cTui, func() {
-			tree.SetBorderColor(tcell.ColorDefault)
-			cTui.paneControls.SetText("Toggle Follow\t[F]")
+			tree.SetBorderColor(colours.Colour(theme.FOCUSED_BORDER))
+			cTui.paneControls.SetText(cTui.keys.help(stepsHelp))
 		})
 	})
 
@@ -87,6 +83,7 @@ func (cTui *CirclogTui) newStepsPane() stepsPane {
 	return stepsPane{
 		tree:        tree,
 		follow:      false,
+		collapsed:   map[string]bool{},
 		watchCtx:    watchCtx,
 		watchCancel: watchCancel,
 	}
@@ -110,39 +107,7 @@ LOOP:
 
 		case jobDetails := <-stepsChan:
 			cTui.app.QueueUpdateDraw(func() {
-				if len(s.tree.GetRoot().GetChildren()) > 0 {
-					if s.tree.GetRoot().GetChildren()[0].GetText() != "None" {
-						currentNode := s.tree.GetCurrentNode().GetReference().(circleci.Action)
-						s.clear()
-						s.populateStepsTree(cTui.state.job, jobDetails)
-						if s.follow {
-							steps := s.tree.GetRoot().GetChildren()
-							latestStepActions := steps[len(steps)-1].GetChildren()
-							for n := len(latestStepActions) - 1; n >= 0; n-- {
-								if n == 0 {
-									s.tree.SetCurrentNode(latestStepActions[n])
-									cTui.logs.restartWatcher(cTui, func() {
-										cTui.state.action = latestStepActions[n].GetReference().(circleci.Action)
-									})
-								} else if latestStepActions[n].GetReference().(circleci.Action).Status == "running" {
-									s.tree.SetCurrentNode(latestStepActions[n])
-									cTui.logs.restartWatcher(cTui, func() {
-										cTui.state.action = latestStepActions[n].GetReference().(circleci.Action)
-									})
-								}
-							}
-						} else {
-							for _, step := range s.tree.GetRoot().GetChildren() {
-								for _, action := range step.GetChildren() {
-									node := action.GetReference().(circleci.Action)
-									if node.Step == currentNode.Step && node.Index == currentNode.Index {
-										s.tree.SetCurrentNode(action)
-									}
-								}
-							}
-						}
-					}
-				}
+				s.refresh(cTui, jobDetails)
 			})
 
 			<-ticker.C
@@ -150,6 +115,56 @@ LOOP:
 	}
 }
 
+func (s *stepsPane) refresh(cTui *CirclogTui, jobDetails circleci.JobDetails) {
+	if len(s.tree.GetRoot().GetChildren()) == 0 || s.tree.GetRoot().GetChildren()[0].GetText() == "None" {
+		return
+	}
+
+	s.clear()
+	s.populateStepsTree(cTui.state.job, jobDetails)
+	if !s.follow {
+		s.showSelected()
+		return
+	}
+
+	steps := s.tree.GetRoot().GetChildren()
+	latestStepActions := steps[len(steps)-1].GetChildren()
+	for n := len(latestStepActions) - 1; n >= 0; n-- {
+		if n == 0 {
+			s.tree.SetCurrentNode(latestStepActions[n])
+			cTui.logs.restartWatcher(cTui, func() {
+				cTui.state.action = latestStepActions[n].GetReference().(circleci.Action)
+			})
+		} else if latestStepActions[n].GetReference().(circleci.Action).Status == "running" {
+			s.tree.SetCurrentNode(latestStepActions[n])
+			cTui.logs.restartWatcher(cTui, func() {
+				cTui.state.action = latestStepActions[n].GetReference().(circleci.Action)
+			})
+		}
+	}
+}
+
+// showSelected makes the node of the selected action current again. While its
+// step is collapsed the tree moves to another node on the next draw, which is
+// ignored so the selection is restored once the step is expanded
+func (s *stepsPane) showSelected() {
+	s.hiding = false
+	if s.selected == nil {
+		return
+	}
+
+	for _, step := range s.tree.GetRoot().GetChildren() {
+		for _, node := range step.GetChildren() {
+			action, ok := node.GetReference().(circleci.Action)
+			if ok && action.Step == s.selected.Step && action.Index == s.selected.Index {
+				s.tree.SetCurrentNode(node)
+				s.hiding = !step.IsExpanded()
+				return
+			}
+		}
+	}
+}
+
 func (s *stepsPane) restartWatcher(cTui *CirclogTui, fn func()) {
 	s.watchCancel()
 	fn()
@@ -157,6 +172,43 @@ func (s *stepsPane) restartWatcher(cTui *CirclogTui, fn func()) {
 	go s.watchSteps(s.watchCtx, cTui)
 }
 
+func (s *stepsPane) runAction(cTui *CirclogTui, action action) bool {
+	switch action {
+
+	case actionBack:
+		cTui.logs.watchCancel()
+		s.watchCancel()
+		cTui.logs.clear()
+		s.clear()
+		s.tree.SetBorderColor(colours.Colour(theme.BORDER))
+		cTui.app.SetFocus(cTui.jobs.table)
+
+	case actionToggleFollow:
+		toggleFollow(cTui)
+		cTui.app.SetFocus(cTui.logs.view)
+
+	case actionBranchSelect:
+		cTui.clearAll()
+		cTui.config.Branch = ""
+		cTui.app.SetFocus(cTui.branchSelect)
+
+	case actionYank:
+		cTui.openYankMenu(cTui.jobYankItems(cTui.stepsCommand(cTui.state.job), cTui.state.job))
+
+	case actionBrowse:
+		cTui.openInBrowser(cTui.jobWebUrl(cTui.state.job))
+
+	case actionDump:
+		cTui.app.Stop()
+		fmt.Println(cTui.stepsCommand(cTui.state.job))
+
+	default:
+		return false
+	}
+
+	return true
+}
+
 func (s *stepsPane) populateStepsTree(job circleci.Job, jobDetails circleci.JobDetails) {
 	jobNode := tview.NewTreeNode(job.Name)
 
@@ -169,6 +221,7 @@ func (s *stepsPane) populateStepsTree(job circleci.Job, jobDetails circleci.JobD
 		for i, step := range jobDetails.Steps {
 			stepNode := tview.NewTreeNode(step.Name).
 				SetSelectable(false).
+				SetExpanded(!s.collapsed[step.Name]).
 				SetReference(step)
 			jobNode.AddChild(stepNode)
 			for _, action := range step.Actions {
@@ -186,7 +239,7 @@ func (s *stepsPane) populateStepsTree(job circleci.Job, jobDetails circleci.JobD
 				actionNode := tview.NewTreeNode(fmt.Sprintf(" %d (%s)", action.Index, actionDuration)).
 					SetSelectable(true).
 					SetReference(action).
-					SetColor(colourByStatus[action.Status])
+					SetColor(colours.Colour(action.Status))
 
 				stepNode.AddChild(actionNode)
 			}
@@ -198,6 +251,17 @@ func (s *stepsPane) populateStepsTree(job circleci.Job, jobDetails circleci.JobD
 
 }
 
+// toggleStep expands or collapses the actions of a step, which is remembered
+// as the tree is refreshed
+func (s *stepsPane) toggleStep(node *tview.TreeNode) {
+	step, ok := node.GetReference().(circleci.Step)
+	if ok {
+		s.collapsed[step.Name] = node.IsExpanded()
+		node.SetExpanded(!node.IsExpanded())
+		s.showSelected()
+	}
+}
+
 func (s *stepsPane) clear() {
 	stepNodes := s.tree.GetRowCount()
 	if stepNodes > 0 {
@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jedrw/circlog/circleci"
+	"github.com/jedrw/circlog/theme"
+)
+
+func TestCollapseAllStepsAndRefresh(t *testing.T) {
+	var err error
+	colours, err = theme.New("dark", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	screen := tcell.NewSimulationScreen("")
+	err = screen.Init()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	defer screen.Fini()
+	screen.SetSize(80, 20)
+
+	cTui := &CirclogTui{}
+	cTui.steps = cTui.newStepsPane()
+	cTui.steps.tree.SetRect(0, 0, 80, 20)
+	draw := func() {
+		cTui.steps.tree.Draw(screen)
+	}
+
+	jobDetails := circleci.JobDetails{Steps: []circleci.Step{
+		{Name: "checkout", Actions: []circleci.Action{{Step: 0, Index: 0}}},
+		{Name: "test", Actions: []circleci.Action{{Step: 1, Index: 0}, {Step: 1, Index: 1}}},
+	}}
+
+	cTui.steps.populateStepsTree(cTui.state.job, jobDetails)
+	draw()
+
+	steps := cTui.steps.tree.GetRoot().GetChildren()
+	cTui.steps.tree.SetCurrentNode(steps[1].GetChildren()[1])
+	draw()
+
+	for _, step := range steps {
+		cTui.steps.toggleStep(step)
+		draw()
+	}
+
+	if cTui.steps.tree.GetCurrentNode() != nil {
+		t.Errorf("expected no current node with every step collapsed")
+	}
+
+	cTui.steps.refresh(cTui, jobDetails)
+	draw()
+
+	want := circleci.Action{Step: 1, Index: 1}
+	if cTui.steps.selected == nil || *cTui.steps.selected != want {
+		t.Fatalf("expected the selected action to be kept, got %v", cTui.steps.selected)
+	}
+
+	steps = cTui.steps.tree.GetRoot().GetChildren()
+	cTui.steps.toggleStep(steps[0])
+	draw()
+
+	action, ok := cTui.steps.tree.GetCurrentNode().GetReference().(circleci.Action)
+	if !ok || action.Step != 0 {
+		t.Errorf("expected the current node to move to the expanded step, got %v", action)
+	}
+
+	if *cTui.steps.selected != want {
+		t.Errorf("expected the selected action to be kept, got %v", cTui.steps.selected)
+	}
+
+	cTui.steps.refresh(cTui, jobDetails)
+	draw()
+
+	steps = cTui.steps.tree.GetRoot().GetChildren()
+	cTui.steps.toggleStep(steps[1])
+	draw()
+
+	action, ok = cTui.steps.tree.GetCurrentNode().GetReference().(circleci.Action)
+	if !ok || action != want {
+		t.Errorf("expected the selected action to be current again, got %v", action)
+	}
+}
@@ -1,12 +1,16 @@
 package tui
 
 import (
+	"path"
+
 	"github.com/gdamore/tcell/v2"
 	"github.com/jedrw/circlog/circleci"
+	"github.com/jedrw/circlog/theme"
+	"github.com/rivo/tview"
 )
 
 func styleForStatus(status string) tcell.Style {
-	return tcell.StyleDefault.Background(tcell.ColorDefault).Foreground(colourByStatus[status])
+	return colours.Style(status)
 }
 
 func branchOrTag(pipeline circleci.Pipeline) string {
@@ -18,6 +22,24 @@ func branchOrTag(pipeline circleci.Pipeline) string {
 	return branchOrTag
 }
 
+func pipelineProject(pipeline circleci.Pipeline) string {
+	return path.Base(pipeline.ProjectSlug)
+}
+
+// loadMoreCell returns the "..." cell at row of table which loads the next page
+// when selected or clicked
+func loadMoreCell(table *tview.Table, row int, nextPageToken string) *tview.TableCell {
+	cell := tview.NewTableCell("...")
+	cell.SetReference(nextPageToken)
+	cell.SetClickedFunc(func() bool {
+		table.Select(row, 0)
+		table.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), nil)
+		return true
+	})
+
+	return cell
+}
+
 func (cTui *CirclogTui) clearAll() {
 	cTui.watchCancelAll()
 
@@ -25,7 +47,7 @@ func (cTui *CirclogTui) clearAll() {
 	cTui.workflows.numPages = 1
 	cTui.pipelines.numPages = 1
 
-	cTui.logs.view.Clear()
+	cTui.logs.clear()
 	cTui.steps.clear()
 	cTui.jobs.clear()
 	cTui.workflows.clear()
@@ -39,3 +61,13 @@ func (cTui *CirclogTui) watchCancelAll() {
 	cTui.workflows.watchCancel()
 	cTui.pipelines.watchCancel()
 }
+
+// applyTheme sets the default colours of tview's primitives, which must be
+// done before any are created
+func applyTheme() {
+	tview.Styles.PrimaryTextColor = colours.Colour(theme.TEXT)
+	tview.Styles.TitleColor = colours.Colour(theme.TEXT)
+	tview.Styles.BorderColor = colours.Colour(theme.BORDER)
+	tview.Styles.GraphicsColor = colours.Colour(theme.BORDER)
+	tview.Styles.SecondaryTextColor = colours.Colour(theme.ACCENT)
+}
@@ -0,0 +1,247 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/jedrw/circlog/circleci"
+	"github.com/jedrw/circlog/config"
+	"github.com/jedrw/circlog/notify"
+)
+
+const (
+	watchInterval = 10 * time.Second
+	watchedMarker = "● "
+)
+
+type watchedItem struct {
+	project    string
+	kind       string
+	id         string
+	name       string
+	status     string
+	pipeline   circleci.Pipeline
+	workflowId string
+}
+
+type watchlist struct {
+	mutex    sync.Mutex
+	items    map[string]*watchedItem
+	notifier notify.Notifier
+	// report shows a message from the watch goroutine
+	report func(message string)
+}
+
+func newWatchlist(notifier notify.Notifier, report func(message string)) *watchlist {
+	return &watchlist{
+		items:    map[string]*watchedItem{},
+		notifier: notifier,
+		report:   report,
+	}
+}
+
+func watchedPipeline(project string, pipeline circleci.Pipeline) watchedItem {
+	return watchedItem{
+		project:  project,
+		kind:     "pipeline",
+		id:       pipeline.Id,
+		name:     fmt.Sprint(pipeline.Number),
+		pipeline: pipeline,
+	}
+}
+
+func watchedWorkflow(project string, workflow circleci.Workflow) watchedItem {
+	return watchedItem{
+		project: project,
+		kind:    "workflow",
+		id:      workflow.Id,
+		name:    workflow.Name,
+		status:  workflow.Status,
+	}
+}
+
+func watchedJob(project string, job circleci.Job, workflow circleci.Workflow) watchedItem {
+	return watchedItem{
+		project:    project,
+		kind:       "job",
+		id:         job.Id,
+		name:       job.Name,
+		status:     job.Status,
+		workflowId: workflow.Id,
+	}
+}
+
+// terminalWriter writes to the terminal from the event loop, so that the
+// escape sequences of notifications are not written in the middle of a draw
+type terminalWriter struct {
+	cTui *CirclogTui
+}
+
+func (writer terminalWriter) Write(p []byte) (int, error) {
+	b := slices.Clone(p)
+	writer.cTui.app.QueueUpdate(func() {
+		os.Stdout.Write(b)
+	})
+
+	return len(p), nil
+}
+
+func (item watchedItem) finished() bool {
+	return circleci.IsTerminalStatus(item.status) || (item.kind == "pipeline" && item.pipeline.State == circleci.ERRORED)
+}
+
+// toggleWatch starts or stops watching item, explaining why when an item that
+// has already finished cannot be watched
+func (cTui *CirclogTui) toggleWatch(item watchedItem) {
+	if cTui.watchlist.isWatched(item.id) || item.kind != "pipeline" || item.finished() {
+		cTui.startWatching(item)
+		return
+	}
+
+	// The status of a pipeline comes from its workflows, without which a
+	// pipeline that has already finished would be reported on the next poll
+	go func() {
+		status, _, err := item.currentStatus(cTui.config)
+		cTui.app.QueueUpdateDraw(func() {
+			if err != nil {
+				cTui.flash(fmt.Sprintf("Could not watch pipeline %s: %s", item.name, err))
+				return
+			}
+
+			item.status = status
+			cTui.startWatching(item)
+		})
+	}()
+}
+
+func (cTui *CirclogTui) startWatching(item watchedItem) {
+	if !cTui.watchlist.isWatched(item.id) && item.finished() {
+		cTui.flash(fmt.Sprintf("Not watching %s %s, it has already finished", item.kind, item.name))
+		return
+	}
+
+	cTui.watchlist.toggle(item)
+}
+
+// toggle starts or stops watching item, returning whether it is now watched.
+// Items that have already finished are not watched.
+func (w *watchlist) toggle(item watchedItem) bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if _, ok := w.items[item.id]; ok {
+		delete(w.items, item.id)
+		return false
+	}
+
+	if item.finished() {
+		return false
+	}
+
+	w.items[item.id] = &item
+
+	return true
+}
+
+func (w *watchlist) isWatched(id string) bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	_, ok := w.items[id]
+
+	return ok
+}
+
+func (w *watchlist) watch(ctx context.Context, config config.CirclogConfig) {
+	ticker := time.NewTicker(watchInterval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			ticker.Stop()
+			return
+
+		case <-ticker.C:
+			w.poll(config)
+		}
+	}
+}
+
+func (w *watchlist) poll(config config.CirclogConfig) {
+	w.mutex.Lock()
+	items := make([]watchedItem, 0, len(w.items))
+	for _, item := range w.items {
+		items = append(items, *item)
+	}
+	w.mutex.Unlock()
+
+	for _, item := range items {
+		status, duration, err := item.currentStatus(config)
+		if err != nil || status == item.status {
+			continue
+		}
+
+		w.mutex.Lock()
+		if circleci.IsTerminalStatus(status) {
+			delete(w.items, item.id)
+		} else if watched, ok := w.items[item.id]; ok {
+			watched.status = status
+		}
+		w.mutex.Unlock()
+
+		if circleci.IsTerminalStatus(status) {
+			err := w.notifier.Notify(notify.Notification{
+				Project:  item.project,
+				Kind:     item.kind,
+				Name:     item.name,
+				Status:   status,
+				Duration: duration,
+			})
+			if err != nil {
+				w.report(fmt.Sprintf("Could not notify: %s", err))
+			}
+		}
+	}
+}
+
+func (item watchedItem) currentStatus(config config.CirclogConfig) (string, time.Duration, error) {
+	switch item.kind {
+	case "pipeline":
+		workflows, _, err := circleci.GetPipelineWorkflows(config, item.id, -1, "")
+		if err != nil {
+			return "", 0, err
+		}
+
+		stoppedAt := item.pipeline.CreatedAt
+		for _, workflow := range workflows {
+			if workflow.StoppedAt.After(stoppedAt) {
+				stoppedAt = workflow.StoppedAt
+			}
+		}
+
+		return circleci.PipelineStatus(workflows), stoppedAt.Sub(item.pipeline.CreatedAt).Round(time.Millisecond), nil
+
+	case "workflow":
+		workflow, err := circleci.GetWorkflow(config, item.id)
+
+		return workflow.Status, workflow.Duration(), err
+
+	case "job":
+		jobs, _, err := circleci.GetWorkflowJobs(config, item.workflowId, -1, "")
+		if err != nil {
+			return "", 0, err
+		}
+
+		for _, job := range jobs {
+			if job.Id == item.id {
+				return job.Status, job.Duration(), nil
+			}
+		}
+	}
+
+	return item.status, 0, nil
+}
@@ -7,11 +7,14 @@ import (
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/jedrw/circlog/circleci"
+	"github.com/jedrw/circlog/theme"
 	"github.com/rivo/tview"
 )
 
 type workflowsPane struct {
 	table       *tview.Table
+	watchlist   *watchlist
+	pager       *circleci.Pager[circleci.Workflow]
 	numPages    int
 	watchCtx    context.Context
 	watchCancel context.CancelFunc
@@ -22,7 +25,7 @@ func (cTui *CirclogTui) newWorkflowsPane() workflowsPane {
 	table.SetTitle(" WORKFLOWS ")
 	table.SetBackgroundColor(tcell.ColorDefault)
 	table.SetBorder(true)
-	table.SetBorderColor(tcell.ColorGrey)
+	table.SetBorderColor(colours.Colour(theme.BORDER))
 	table.SetSelectable(true, false).SetFixed(1, 0).SetSeparator(tview.Borders.Vertical)
 
 	for column, header := range []string{"Name", "Duration"} {
@@ -36,51 +39,34 @@ func (cTui *CirclogTui) newWorkflowsPane() workflowsPane {
 
 		case circleci.Workflow:
 			cTui.state.workflow = cellRef
-			jobs, nextPageToken, _ := circleci.GetWorkflowJobs(cTui.config, cTui.state.workflow.Id, 1, "")
-			cTui.jobs.populateTable(jobs, nextPageToken)
+			cTui.jobs.loadFirstPage(cTui)
 			cTui.app.SetFocus(cTui.jobs.table)
 
 		case string:
 			if cell.Text == "..." {
 				cTui.workflows.restartWatcher(cTui, func() {
-					nextPageToken := cell.GetReference().(string)
-					newWorkflows, nextPageToken, _ := circleci.GetPipelineWorkflows(cTui.config, cTui.state.pipeline.Id, 1, nextPageToken)
-					cTui.workflows.addWorkflowsToTable(newWorkflows, nextPageToken)
-					cTui.workflows.numPages++
+					err := cTui.workflows.loadNextPage()
+					if err != nil {
+						cTui.flash(fmt.Sprintf("Could not load workflows: %s", err))
+					}
 				})
 			}
 		}
 	})
 
 	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		if event.Key() == tcell.KeyEsc {
-			cTui.workflows.watchCancel()
-			cTui.workflows.clear()
-			table.SetBorderColor(tcell.ColorGrey)
-			cTui.app.SetFocus(cTui.pipelines.table)
-
-			return event
-		}
-
-		switch event.Rune() {
-
-		case 'b':
-			cTui.clearAll()
-			cTui.config.Branch = ""
-			cTui.app.SetFocus(cTui.branchSelect)
-
-		case 'd':
-			cTui.app.Stop()
-			fmt.Printf("circlog workflows %s -l %s\n", cTui.config.Project, cTui.state.pipeline.Id)
+		if cTui.runAction(&cTui.workflows, cTui.keys.match(event, workflowsActions)) {
+			return nil
 		}
 
 		return event
 	})
 
 	table.SetFocusFunc(func() {
+		cTui.saveSession()
 		cTui.workflows.restartWatcher(cTui, func() {
-			table.SetBorderColor(tcell.ColorDefault)
-			cTui.paneControls.Clear()
+			table.SetBorderColor(colours.Colour(theme.FOCUSED_BORDER))
+			cTui.paneControls.SetText(cTui.keys.help(workflowsHelp))
 		})
 	})
 
@@ -88,6 +74,7 @@ func (cTui *CirclogTui) newWorkflowsPane() workflowsPane {
 
 	return workflowsPane{
 		table:       table,
+		watchlist:   cTui.watchlist,
 		numPages:    1,
 		watchCtx:    watchCtx,
 		watchCancel: watchCancel,
@@ -102,7 +89,7 @@ func (w *workflowsPane) watchWorkflows(ctx context.Context, cTui *CirclogTui) {
 LOOP:
 	for {
 		go func() {
-			workflows, nextPageToken, _ := circleci.GetPipelineWorkflows(cTui.config, cTui.state.pipeline.Id, cTui.pipelines.numPages, "")
+			workflows, nextPageToken, _ := circleci.GetPipelineWorkflows(cTui.config, cTui.state.pipeline.Id, w.numPages, "")
 			workflowsChan <- workflows
 			nextPageTokenChan <- nextPageToken
 		}()
@@ -116,7 +103,7 @@ LOOP:
 			nextPageToken := <-nextPageTokenChan
 			cTui.app.QueueUpdateDraw(func() {
 				w.clear()
-				w.addWorkflowsToTable(workflows, nextPageToken)
+				w.addWorkflowsToTable(workflows, 1, nextPageToken)
 			})
 
 			<-ticker.C
@@ -131,36 +118,100 @@ func (w *workflowsPane) restartWatcher(cTui *CirclogTui, fn func()) {
 	go w.watchWorkflows(w.watchCtx, cTui)
 }
 
-func (w *workflowsPane) populateWorkflowsTable(workflows []circleci.Workflow, nextPageToken string) {
+func (w *workflowsPane) runAction(cTui *CirclogTui, action action) bool {
+	switch action {
+
+	case actionBack:
+		w.watchCancel()
+		w.clear()
+		w.table.SetBorderColor(colours.Colour(theme.BORDER))
+		cTui.app.SetFocus(cTui.pipelines.table)
+
+	case actionWatch:
+		cell := w.table.GetCell(w.table.GetSelection())
+		cellRef, ok := cell.GetReference().(circleci.Workflow)
+		if ok {
+			w.restartWatcher(cTui, func() {
+				cTui.toggleWatch(watchedWorkflow(cTui.config.Project, cellRef))
+			})
+		}
+
+	case actionBranchSelect:
+		cTui.clearAll()
+		cTui.config.Branch = ""
+		cTui.app.SetFocus(cTui.branchSelect)
+
+	case actionYank:
+		cell := w.table.GetCell(w.table.GetSelection())
+		cellRef, ok := cell.GetReference().(circleci.Workflow)
+		if ok {
+			cTui.openYankMenu(cTui.workflowYankItems(cellRef))
+		}
+
+	case actionBrowse:
+		cell := w.table.GetCell(w.table.GetSelection())
+		cellRef, ok := cell.GetReference().(circleci.Workflow)
+		if ok {
+			cTui.openInBrowser(circleci.WorkflowWebUrl(cTui.config, cellRef.PipelineNumber, cellRef.Id))
+		}
+
+	case actionDump:
+		cTui.app.Stop()
+		fmt.Println(cTui.workflowsCommand(cTui.state.pipeline))
+
+	default:
+		return false
+	}
+
+	return true
+}
+
+func (w *workflowsPane) loadFirstPage(cTui *CirclogTui) {
+	w.pager = circleci.PipelineWorkflowsPager(cTui.config, cTui.state.pipeline.Id, circleci.WorkflowFilter{}, "")
+	w.numPages = 1
+	workflows, _ := w.pager.NextPage()
+	w.populateTable(workflows, w.pager.NextPageToken())
+}
+
+func (w *workflowsPane) loadNextPage() error {
+	workflows, err := w.pager.NextPage()
+	if err != nil {
+		return err
+	}
+
+	w.addWorkflowsToTable(workflows, w.table.GetRowCount()-1, w.pager.NextPageToken())
+	w.numPages++
+
+	return nil
+}
+
+func (w *workflowsPane) populateTable(workflows []circleci.Workflow, nextPageToken string) {
 	w.clear()
-	w.addWorkflowsToTable(workflows, nextPageToken)
+	w.addWorkflowsToTable(workflows, w.table.GetRowCount(), nextPageToken)
 }
 
-func (w *workflowsPane) addWorkflowsToTable(workflows []circleci.Workflow, nextPageToken string) {
+func (w *workflowsPane) addWorkflowsToTable(workflows []circleci.Workflow, startRow int, nextPageToken string) {
 	if len(workflows) != 0 {
 		for row, workflow := range workflows {
-			var workflowDuration string
-			if workflow.Status == circleci.RUNNING {
-				workflowDuration = time.Since(workflow.CreatedAt).Round(time.Millisecond).String()
-			} else {
-				workflowDuration = workflow.StoppedAt.Sub(workflow.CreatedAt).Round(time.Millisecond).String()
+			name := workflow.Name
+			if w.watchlist.isWatched(workflow.Id) {
+				name = watchedMarker + name
 			}
 
-			for column, attr := range []string{workflow.Name, workflowDuration} {
+			for column, attr := range []string{name, workflow.Duration().String()} {
 				cell := tview.NewTableCell(attr).SetStyle(styleForStatus(workflow.Status))
 				cell.SetReference(workflow)
-				w.table.SetCell(row+1, column, cell)
+				w.table.SetCell(row+startRow, column, cell)
 			}
 		}
 
 		if nextPageToken != "" {
-			cell := tview.NewTableCell("...")
-			cell.SetReference(nextPageToken)
-			w.table.SetCell(w.table.GetRowCount(), 0, cell)
+			row := w.table.GetRowCount()
+			w.table.SetCell(row, 0, loadMoreCell(w.table, row, nextPageToken))
 		}
 
 	} else {
-		cell := tview.NewTableCell("None").SetStyle(tcell.StyleDefault.Background(tcell.ColorDefault).Foreground(tcell.ColorDarkGray))
+		cell := tview.NewTableCell("None").SetStyle(colours.Style(theme.MUTED))
 		w.table.SetCell(1, 0, cell)
 	}
 }
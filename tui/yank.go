@@ -0,0 +1,95 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jedrw/circlog/circleci"
+	"github.com/jedrw/circlog/theme"
+	"github.com/rivo/tview"
+)
+
+const maxYankWidth = 100
+
+type yankItem struct {
+	label string
+	text  string
+}
+
+// openYankMenu lets one of items be copied to the clipboard
+func (cTui *CirclogTui) openYankMenu(items []yankItem) {
+	list := tview.NewList()
+	list.SetTitle(" YANK ").SetBorder(true).SetBorderPadding(0, 0, 1, 1)
+	list.SetBackgroundColor(tcell.ColorDefault)
+	list.SetMainTextStyle(tcell.StyleDefault.Background(tcell.ColorDefault))
+	list.SetSecondaryTextStyle(colours.Style(theme.MUTED))
+	list.SetShortcutStyle(colours.Style(theme.ACCENT))
+
+	width := len(list.GetTitle())
+	for _, item := range items {
+		if item.text == "" {
+			continue
+		}
+
+		list.AddItem(item.label, item.text, rune('1'+list.GetItemCount()), func() {
+			cTui.hideModal("yank")
+			err := cTui.clipboard.Copy(item.text)
+			if err != nil {
+				cTui.flash(fmt.Sprintf("Could not copy: %s", err))
+			} else {
+				cTui.flash(fmt.Sprintf("Copied %s", item.label))
+			}
+		})
+
+		width = max(width, len(item.label)+4, len(item.text))
+	}
+
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc || event.Rune() == 'q' {
+			cTui.hideModal("yank")
+
+			return nil
+		}
+
+		return event
+	})
+
+	cTui.showModal("yank", list, min(width+4, maxYankWidth), list.GetItemCount()*2+2)
+}
+
+func (cTui *CirclogTui) pipelineYankItems(pipeline circleci.Pipeline) []yankItem {
+	return []yankItem{
+		{"Command", cTui.pipelinesCommand()},
+		{"Pipeline ID", pipeline.Id},
+		{"Pipeline number", fmt.Sprint(pipeline.Number)},
+		{"URL", circleci.PipelineWebUrl(cTui.config, pipeline.Number)},
+		{"Commit SHA", pipeline.Vcs.Revision},
+	}
+}
+
+func (cTui *CirclogTui) workflowYankItems(workflow circleci.Workflow) []yankItem {
+	return []yankItem{
+		{"Command", cTui.workflowsCommand(cTui.state.pipeline)},
+		{"Workflow ID", workflow.Id},
+		{"Pipeline ID", workflow.PipelineId},
+		{"URL", circleci.WorkflowWebUrl(cTui.config, workflow.PipelineNumber, workflow.Id)},
+		{"Commit SHA", cTui.state.pipeline.Vcs.Revision},
+	}
+}
+
+func (cTui *CirclogTui) jobYankItems(command string, job circleci.Job) []yankItem {
+	items := []yankItem{
+		{"Command", command},
+		{"Job ID", job.Id},
+	}
+
+	// Approval jobs have no number
+	if job.JobNumber != 0 {
+		items = append(items, yankItem{"Job number", fmt.Sprint(job.JobNumber)})
+	}
+
+	return append(items,
+		yankItem{"URL", cTui.jobWebUrl(job)},
+		yankItem{"Commit SHA", cTui.state.pipeline.Vcs.Revision},
+	)
+}